@@ -64,21 +64,25 @@ type addMCPServerDialogCmp struct {
 	onSubmit func(req *loomv1.AddMCPServerRequest) tea.Cmd
 
 	// Test state
-	testStatus    string // "", "testing", "success", "error"
-	testMessage   string
-	testError     string
-	testToolCount int32
-	testLatency   int64
-	testPassed    bool
+	testStatus        string // "", "testing", "success", "error"
+	testMessage       string
+	testError         string
+	testToolCount     int32
+	testResourceCount int32
+	testPromptCount   int32
+	testLatency       int64
+	testPassed        bool
 }
 
 // TestMCPServerResultMsg is sent when test completes
 type TestMCPServerResultMsg struct {
-	Success   bool
-	Message   string
-	Error     string
-	ToolCount int32
-	Latency   int64
+	Success       bool
+	Message       string
+	Error         string
+	ToolCount     int32
+	ResourceCount int32
+	PromptCount   int32
+	Latency       int64
 }
 
 func NewAddMCPServerDialog(
@@ -97,7 +101,7 @@ func NewAddMCPServerDialog(
 	inputs[fieldName].SetStyles(t.S().TextInput)
 	inputs[fieldName].Focus()
 
-	// Command
+	// Command (doubles as URL for http/sse/websocket, socket path for unix)
 	inputs[fieldCommand] = textinput.New()
 	inputs[fieldCommand].Placeholder = "/path/to/mcp-server"
 	inputs[fieldCommand].SetWidth(50)
@@ -171,6 +175,8 @@ func (c *addMCPServerDialogCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 		c.testMessage = msg.Message
 		c.testError = msg.Error
 		c.testToolCount = msg.ToolCount
+		c.testResourceCount = msg.ResourceCount
+		c.testPromptCount = msg.PromptCount
 		c.testLatency = msg.Latency
 		return c, nil
 
@@ -231,20 +237,36 @@ func (c *addMCPServerDialogCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 	return c, nil
 }
 
+// isNetworkTransport reports whether transport addresses a remote endpoint
+// (URL + headers) rather than a local command (command + env vars + cwd).
+func isNetworkTransport(transport string) bool {
+	switch transport {
+	case "http", "sse", "websocket":
+		return true
+	default:
+		return false
+	}
+}
+
+// isSocketTransport reports whether transport connects to a local Unix
+// domain socket, which needs neither a command nor a URL.
+func isSocketTransport(transport string) bool {
+	return transport == "unix"
+}
+
 // testConnection runs the test connection RPC
 func (c *addMCPServerDialogCmp) testConnection() tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 35*time.Second)
 		defer cancel()
 
+		transport := c.inputs[fieldTransport].Value()
 		req := &loomv1.TestMCPServerConnectionRequest{
-			Command:        c.inputs[fieldCommand].Value(),
-			Args:           c.parseArgs(c.inputs[fieldArgs].Value()),
-			Env:            c.parseEnvVars(c.inputs[fieldEnvVars].Value()),
-			Transport:      c.inputs[fieldTransport].Value(),
+			Transport:      transport,
 			WorkingDir:     c.inputs[fieldWorkingDir].Value(),
 			TimeoutSeconds: 30,
 		}
+		c.applyEndpointFields(transport, req)
 
 		resp, err := c.client.TestMCPServerConnection(ctx, req)
 		if err != nil {
@@ -255,25 +277,56 @@ func (c *addMCPServerDialogCmp) testConnection() tea.Cmd {
 		}
 
 		return TestMCPServerResultMsg{
-			Success:   resp.Success,
-			Message:   resp.Message,
-			Error:     resp.Error,
-			ToolCount: resp.ToolCount,
-			Latency:   resp.LatencyMs,
+			Success:       resp.Success,
+			Message:       resp.Message,
+			Error:         resp.Error,
+			ToolCount:     resp.ToolCount,
+			ResourceCount: resp.ResourceCount,
+			PromptCount:   resp.PromptCount,
+			Latency:       resp.LatencyMs,
 		}
 	}
 }
 
+// applyEndpointFields fills in the fields fieldCommand/fieldEnvVars map to
+// depending on the selected transport: Command+Env for stdio, URL+Headers
+// for http/sse/websocket, SocketPath (no Env) for unix.
+func (c *addMCPServerDialogCmp) applyEndpointFields(transport string, req *loomv1.TestMCPServerConnectionRequest) {
+	switch {
+	case isSocketTransport(transport):
+		req.SocketPath = c.inputs[fieldCommand].Value()
+	case isNetworkTransport(transport):
+		req.Url = c.inputs[fieldCommand].Value()
+		req.Headers = c.parseEnvVars(c.inputs[fieldEnvVars].Value())
+	default:
+		req.Command = c.inputs[fieldCommand].Value()
+		req.Args = c.parseArgs(c.inputs[fieldArgs].Value())
+		req.Env = c.parseEnvVars(c.inputs[fieldEnvVars].Value())
+	}
+}
+
 // buildRequest constructs the AddMCPServerRequest from inputs
 func (c *addMCPServerDialogCmp) buildRequest() *loomv1.AddMCPServerRequest {
-	return &loomv1.AddMCPServerRequest{
+	transport := c.inputs[fieldTransport].Value()
+	req := &loomv1.AddMCPServerRequest{
 		Name:       c.inputs[fieldName].Value(),
-		Command:    c.inputs[fieldCommand].Value(),
-		Args:       c.parseArgs(c.inputs[fieldArgs].Value()),
-		Env:        c.parseEnvVars(c.inputs[fieldEnvVars].Value()),
-		Transport:  c.inputs[fieldTransport].Value(),
+		Transport:  transport,
 		WorkingDir: c.inputs[fieldWorkingDir].Value(),
 	}
+
+	switch {
+	case isSocketTransport(transport):
+		req.SocketPath = c.inputs[fieldCommand].Value()
+	case isNetworkTransport(transport):
+		req.Url = c.inputs[fieldCommand].Value()
+		req.Headers = c.parseEnvVars(c.inputs[fieldEnvVars].Value())
+	default:
+		req.Command = c.inputs[fieldCommand].Value()
+		req.Args = c.parseArgs(c.inputs[fieldArgs].Value())
+		req.Env = c.parseEnvVars(c.inputs[fieldEnvVars].Value())
+	}
+
+	return req
 }
 
 // parseArgs splits comma-separated args
@@ -323,7 +376,10 @@ func (c *addMCPServerDialogCmp) View() string {
 		Padding(0, 1).
 		Render("Add MCP Server")
 
-	// Input fields
+	// Input fields. The second to fourth labels swap based on the selected
+	// transport: a local command + env vars for stdio, a URL + headers for
+	// http/sse/websocket, and a socket path (no env vars) for unix.
+	transport := c.inputs[fieldTransport].Value()
 	fieldLabels := []string{
 		"Name*",
 		"Command*",
@@ -332,6 +388,16 @@ func (c *addMCPServerDialogCmp) View() string {
 		"Transport*",
 		"Working Directory",
 	}
+	switch {
+	case isSocketTransport(transport):
+		fieldLabels[fieldCommand] = "Socket Path*"
+		fieldLabels[fieldArgs] = "Arguments (unused)"
+		fieldLabels[fieldEnvVars] = "Environment Variables (unused)"
+	case isNetworkTransport(transport):
+		fieldLabels[fieldCommand] = "Server URL*"
+		fieldLabels[fieldArgs] = "Arguments (unused)"
+		fieldLabels[fieldEnvVars] = "Headers"
+	}
 
 	inputFields := make([]string, len(c.inputs))
 	for i, input := range c.inputs {
@@ -390,7 +456,8 @@ func (c *addMCPServerDialogCmp) renderTestStatus() string {
 	}
 
 	if c.testPassed {
-		statusLine := fmt.Sprintf("‚úÖ Test passed! Discovered %d tools (%dms)", c.testToolCount, c.testLatency)
+		statusLine := fmt.Sprintf("‚úÖ Test passed! Discovered %d tools, %d resources, %d prompts (%dms)",
+			c.testToolCount, c.testResourceCount, c.testPromptCount, c.testLatency)
 		return t.S().Base.
 			Foreground(t.Success).
 			Padding(0, 1).
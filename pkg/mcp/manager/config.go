@@ -47,12 +47,19 @@ type ServerConfig struct {
 	// ToolFilter controls which tools are registered from this server
 	ToolFilter ToolFilter `yaml:"tools" json:"tools"`
 
-	// Transport specifies the transport type ("stdio" or "sse")
+	// Transport specifies the transport type ("stdio", "http", "sse", "websocket", or "unix")
 	Transport string `yaml:"transport" json:"transport"`
 
-	// URL is the server URL (for SSE transport)
+	// URL is the server URL (for http, sse, and websocket transports)
 	URL string `yaml:"url" json:"url"`
 
+	// Headers are additional headers sent during the http/sse/websocket
+	// handshake, e.g. for bearer token auth.
+	Headers map[string]string `yaml:"headers" json:"headers"`
+
+	// SocketPath is the Unix domain socket path (for unix transport)
+	SocketPath string `yaml:"socket_path" json:"socket_path"`
+
 	// Timeout for server operations (e.g., "30s", "1m")
 	Timeout string `yaml:"timeout" json:"timeout"`
 }
@@ -119,12 +126,16 @@ func (s *ServerConfig) Validate() error {
 		if s.Command == "" {
 			return fmt.Errorf("command required for stdio transport")
 		}
-	case "http", "sse":
+	case "http", "sse", "websocket":
 		if s.URL == "" {
-			return fmt.Errorf("url required for http/sse transport")
+			return fmt.Errorf("url required for %s transport", s.Transport)
+		}
+	case "unix":
+		if s.SocketPath == "" {
+			return fmt.Errorf("socket_path required for unix transport")
 		}
 	default:
-		return fmt.Errorf("invalid transport: %s (must be 'stdio', 'http', or 'sse')", s.Transport)
+		return fmt.Errorf("invalid transport: %s (must be 'stdio', 'http', 'sse', 'websocket', or 'unix')", s.Transport)
 	}
 
 	return nil
@@ -116,10 +116,22 @@ func (m *Manager) startServer(ctx context.Context, name string, config ServerCon
 		// HTTP/SSE transport (sse is alias for backwards compatibility)
 		trans, err = transport.NewHTTPTransport(transport.HTTPConfig{
 			Endpoint: config.URL,
+			Headers:  config.Headers,
 			Logger:   m.logger.With(zap.String("server", name)),
 		})
+	case "websocket":
+		trans, err = transport.NewWebSocketTransport(transport.WebSocketConfig{
+			URL:     config.URL,
+			Headers: config.Headers,
+			Logger:  m.logger.With(zap.String("server", name)),
+		})
+	case "unix":
+		trans, err = transport.NewUnixTransport(transport.UnixConfig{
+			SocketPath: config.SocketPath,
+			Logger:     m.logger.With(zap.String("server", name)),
+		})
 	default:
-		return fmt.Errorf("unsupported transport: %s (supported: stdio, http, sse)", config.Transport)
+		return fmt.Errorf("unsupported transport: %s (supported: stdio, http, sse, websocket, unix)", config.Transport)
 	}
 
 	if err != nil {
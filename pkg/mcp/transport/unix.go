@@ -0,0 +1,152 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package transport implements Unix domain socket transport for MCP servers.
+package transport
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// UnixTransport implements Transport over a Unix domain socket, using the
+// same newline-delimited JSON-RPC framing as StdioTransport.
+type UnixTransport struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	mu     sync.Mutex
+	closed bool
+	logger *zap.Logger
+}
+
+// UnixConfig configures the Unix domain socket transport.
+type UnixConfig struct {
+	SocketPath  string        // Path to the Unix domain socket
+	DialTimeout time.Duration // Dial timeout (default: 10s)
+	Logger      *zap.Logger   // Logger
+}
+
+// NewUnixTransport dials a Unix domain socket and returns a Transport.
+func NewUnixTransport(config UnixConfig) (*UnixTransport, error) {
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	if config.DialTimeout == 0 {
+		config.DialTimeout = 10 * time.Second
+	}
+	if config.SocketPath == "" {
+		return nil, fmt.Errorf("socket path is required")
+	}
+
+	conn, err := net.DialTimeout("unix", config.SocketPath, config.DialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial unix socket %s: %w", config.SocketPath, err)
+	}
+
+	config.Logger.Info("MCP unix socket transport connected", zap.String("socket", config.SocketPath))
+
+	return &UnixTransport{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+		logger: config.Logger,
+	}, nil
+}
+
+// Send implements Transport by writing a newline-terminated message to the socket.
+func (u *UnixTransport) Send(ctx context.Context, message []byte) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.closed {
+		return fmt.Errorf("transport closed")
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = u.conn.SetWriteDeadline(deadline)
+	}
+
+	if _, err := u.conn.Write(append(message, '\n')); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	return nil
+}
+
+// Receive implements Transport by reading the next newline-terminated message.
+func (u *UnixTransport) Receive(ctx context.Context) ([]byte, error) {
+	type readResult struct {
+		data []byte
+		err  error
+	}
+	resultChan := make(chan readResult, 1)
+
+	go func() {
+		u.mu.Lock()
+		if u.closed {
+			resultChan <- readResult{nil, fmt.Errorf("transport closed")}
+			u.mu.Unlock()
+			return
+		}
+		u.mu.Unlock()
+
+		data, err := u.reader.ReadBytes('\n')
+		if err != nil {
+			resultChan <- readResult{nil, err}
+			return
+		}
+
+		if len(data) > 0 && data[len(data)-1] == '\n' {
+			data = data[:len(data)-1]
+		}
+		if len(data) > 0 && data[len(data)-1] == '\r' {
+			data = data[:len(data)-1]
+		}
+
+		resultChan <- readResult{data, nil}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-resultChan:
+		return result.data, result.err
+	}
+}
+
+// Close implements Transport by closing the socket connection.
+func (u *UnixTransport) Close() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.closed {
+		return nil
+	}
+	u.closed = true
+
+	u.logger.Info("closing unix socket transport")
+
+	return u.conn.Close()
+}
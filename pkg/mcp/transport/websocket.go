@@ -0,0 +1,383 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package transport implements a minimal RFC 6455 WebSocket client transport
+// for MCP servers. The protocol is implemented with the standard library alone:
+// one text/binary frame per JSON-RPC message, client-to-server frames
+// masked per spec, no compression extensions.
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // required by the RFC 6455 handshake, not used for security
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	wsOpText   = 0x1
+	wsOpBinary = 0x2
+	wsOpClose  = 0x8
+	wsOpPing   = 0x9
+	wsOpPong   = 0xA
+)
+
+// WebSocketTransport implements Transport over a client WebSocket connection.
+type WebSocketTransport struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	mu     sync.Mutex
+	closed bool
+	logger *zap.Logger
+}
+
+// WebSocketConfig configures the WebSocket transport.
+type WebSocketConfig struct {
+	URL         string            // ws:// or wss:// endpoint
+	Headers     map[string]string // Additional handshake headers (e.g. Authorization)
+	DialTimeout time.Duration     // Dial timeout (default: 10s)
+	Logger      *zap.Logger       // Logger
+}
+
+// NewWebSocketTransport performs the RFC 6455 opening handshake and returns a Transport.
+func NewWebSocketTransport(config WebSocketConfig) (*WebSocketTransport, error) {
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	if config.DialTimeout == 0 {
+		config.DialTimeout = 10 * time.Second
+	}
+
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid websocket url: %w", err)
+	}
+
+	network := "tcp"
+	addr := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "wss" {
+			addr = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			addr = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: config.DialTimeout}
+	conn, err := dialer.Dial(network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", addr, err)
+	}
+	if u.Scheme == "wss" {
+		conn, err = upgradeTLS(conn, u.Hostname(), config.DialTimeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reader, _, err := handshake(conn, u, config.Headers)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	config.Logger.Info("MCP websocket transport connected", zap.String("url", config.URL))
+
+	return &WebSocketTransport{
+		conn:   conn,
+		reader: reader,
+		logger: config.Logger,
+	}, nil
+}
+
+// handshake sends the HTTP Upgrade request and validates the server's
+// response. It returns the *bufio.Reader used to read the handshake
+// response so the caller can keep reading from it afterwards: the server
+// may pipeline a frame into the same TCP segment as the 101 response, and
+// that reader may already have buffered bytes past the HTTP headers that a
+// fresh bufio.Reader over conn would never see.
+func handshake(conn net.Conn, u *url.URL, headers map[string]string) (*bufio.Reader, string, error) {
+	keyBytes := make([]byte, 16)
+	if _, err := rand.Read(keyBytes); err != nil {
+		return nil, "", fmt.Errorf("failed to generate websocket key: %w", err)
+	}
+	key := base64.StdEncoding.EncodeToString(keyBytes)
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to build handshake request: %w", err)
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Host = u.Host
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	if err := req.Write(conn); err != nil {
+		return nil, "", fmt.Errorf("failed to write handshake request: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read handshake response: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, "", fmt.Errorf("websocket handshake failed: unexpected status %d", resp.StatusCode)
+	}
+
+	sum := sha1.Sum([]byte(key + websocketGUID)) //nolint:gosec // RFC 6455 fixed algorithm
+	expected := base64.StdEncoding.EncodeToString(sum[:])
+	if resp.Header.Get("Sec-WebSocket-Accept") != expected {
+		return nil, "", fmt.Errorf("websocket handshake failed: invalid Sec-WebSocket-Accept")
+	}
+
+	return reader, key, nil
+}
+
+// Send implements Transport by writing message as a single masked text frame.
+func (w *WebSocketTransport) Send(ctx context.Context, message []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return fmt.Errorf("transport closed")
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = w.conn.SetWriteDeadline(deadline)
+	}
+
+	frame, err := encodeFrame(wsOpText, message)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.conn.Write(frame); err != nil {
+		return fmt.Errorf("failed to write frame: %w", err)
+	}
+
+	return nil
+}
+
+// Receive implements Transport, returning the payload of the next
+// text/binary frame and transparently answering ping frames.
+func (w *WebSocketTransport) Receive(ctx context.Context) ([]byte, error) {
+	type readResult struct {
+		data []byte
+		err  error
+	}
+	resultChan := make(chan readResult, 1)
+
+	go func() {
+		for {
+			w.mu.Lock()
+			if w.closed {
+				resultChan <- readResult{nil, fmt.Errorf("transport closed")}
+				w.mu.Unlock()
+				return
+			}
+			w.mu.Unlock()
+
+			op, payload, err := decodeFrame(w.reader)
+			if err != nil {
+				resultChan <- readResult{nil, err}
+				return
+			}
+
+			switch op {
+			case wsOpPing:
+				_ = w.writePong(payload)
+				continue
+			case wsOpClose:
+				resultChan <- readResult{nil, fmt.Errorf("websocket closed by peer")}
+				return
+			case wsOpPong:
+				continue
+			default:
+				resultChan <- readResult{payload, nil}
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-resultChan:
+		return result.data, result.err
+	}
+}
+
+func (w *WebSocketTransport) writePong(payload []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return fmt.Errorf("transport closed")
+	}
+	frame, err := encodeFrame(wsOpPong, payload)
+	if err != nil {
+		return err
+	}
+	_, err = w.conn.Write(frame)
+	return err
+}
+
+// Close implements Transport by sending a close frame and closing the connection.
+func (w *WebSocketTransport) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if frame, err := encodeFrame(wsOpClose, nil); err == nil {
+		_, _ = w.conn.Write(frame)
+	}
+
+	w.logger.Info("closing websocket transport")
+
+	return w.conn.Close()
+}
+
+// encodeFrame builds a single, final, client-masked WebSocket frame.
+func encodeFrame(opcode byte, payload []byte) ([]byte, error) {
+	mask := make([]byte, 4)
+	if _, err := rand.Read(mask); err != nil {
+		return nil, fmt.Errorf("failed to generate frame mask: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(0x80 | opcode) // FIN + opcode
+
+	masked := byte(0x80)
+	switch {
+	case len(payload) <= 125:
+		buf.WriteByte(masked | byte(len(payload)))
+	case len(payload) <= 0xFFFF:
+		buf.WriteByte(masked | 126)
+		_ = binary.Write(&buf, binary.BigEndian, uint16(len(payload)))
+	default:
+		buf.WriteByte(masked | 127)
+		_ = binary.Write(&buf, binary.BigEndian, uint64(len(payload)))
+	}
+
+	buf.Write(mask)
+	for i, b := range payload {
+		buf.WriteByte(b ^ mask[i%4])
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeFrame reads a single (unmasked, server-to-client) WebSocket frame.
+// Fragmented messages are not supported; MCP JSON-RPC messages are expected
+// to fit in a single frame.
+func decodeFrame(r *bufio.Reader) (byte, []byte, error) {
+	header := make([]byte, 2)
+	if _, err := readFull(r, header); err != nil {
+		return 0, nil, err
+	}
+
+	opcode := header[0] & 0x0F
+	payloadLen := int64(header[1] & 0x7F)
+	masked := header[1]&0x80 != 0
+
+	switch payloadLen {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := readFull(r, ext); err != nil {
+			return 0, nil, err
+		}
+		payloadLen = int64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := readFull(r, ext); err != nil {
+			return 0, nil, err
+		}
+		payloadLen = int64(binary.BigEndian.Uint64(ext))
+	}
+
+	var mask []byte
+	if masked {
+		mask = make([]byte, 4)
+		if _, err := readFull(r, mask); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	payload := make([]byte, payloadLen)
+	if _, err := readFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return opcode, payload, nil
+}
+
+func readFull(r *bufio.Reader, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := r.Read(buf[total:])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// upgradeTLS wraps conn in a TLS client connection for wss:// endpoints.
+func upgradeTLS(conn net.Conn, serverName string, timeout time.Duration) (net.Conn, error) {
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12})
+	if err := tlsConn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to set TLS handshake deadline: %w", err)
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("TLS handshake failed: %w", err)
+	}
+	if err := tlsConn.SetDeadline(time.Time{}); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to clear TLS deadline: %w", err)
+	}
+	return tlsConn, nil
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"math/rand"
+	"time"
 )
 
 // VariantSelector determines which prompt variant to use for A/B testing.
@@ -210,11 +211,17 @@ func (s *WeightedSelector) SelectVariant(ctx context.Context, key string, varian
 //	// Automatically selects variant based on session ID
 //	prompt, _ := abRegistry.GetForSession(ctx, "agent.system", "sess-123", vars)
 type ABTestingRegistry struct {
-	underlying PromptRegistry
-	selector   VariantSelector
+	underlying  PromptRegistry
+	selector    VariantSelector
+	assignments AssignmentStore
+	assignTTL   time.Duration
+	monitor     *VariantMonitor
+	guardrail   GuardrailConfig
 }
 
-// NewABTestingRegistry creates an A/B testing registry wrapper.
+// NewABTestingRegistry creates an A/B testing registry wrapper. Sessions are
+// not sticky across variant-set changes; use
+// NewABTestingRegistryWithAssignments for that.
 func NewABTestingRegistry(underlying PromptRegistry, selector VariantSelector) *ABTestingRegistry {
 	return &ABTestingRegistry{
 		underlying: underlying,
@@ -222,6 +229,21 @@ func NewABTestingRegistry(underlying PromptRegistry, selector VariantSelector) *
 	}
 }
 
+// NewABTestingRegistryWithAssignments creates an A/B testing registry that
+// reuses a session's prior variant assignment (via store) as long as that
+// variant is still present in PromptMetadata.Variants, instead of
+// re-deriving it from the selector every call. This keeps a session on its
+// original variant even if variants are added/removed later. A zero ttl
+// means assignments never expire.
+func NewABTestingRegistryWithAssignments(underlying PromptRegistry, selector VariantSelector, store AssignmentStore, ttl time.Duration) *ABTestingRegistry {
+	return &ABTestingRegistry{
+		underlying:  underlying,
+		selector:    selector,
+		assignments: store,
+		assignTTL:   ttl,
+	}
+}
+
 // Get retrieves a prompt by key with automatic variant selection based on context.
 // Uses "default" as session ID if not found in context.
 func (r *ABTestingRegistry) Get(ctx context.Context, key string, vars map[string]interface{}) (string, error) {
@@ -235,6 +257,10 @@ func (r *ABTestingRegistry) GetWithVariant(ctx context.Context, key string, vari
 }
 
 // GetForSession retrieves a prompt with variant selection based on session ID.
+// If this registry was created with an AssignmentStore, a prior assignment
+// for (key, sessionID) is reused as long as its variant is still present in
+// metadata.Variants; otherwise the selector is consulted and the result is
+// persisted for next time.
 func (r *ABTestingRegistry) GetForSession(ctx context.Context, key string, sessionID string, vars map[string]interface{}) (string, error) {
 	// Get metadata to find available variants
 	metadata, err := r.underlying.GetMetadata(ctx, key)
@@ -242,16 +268,100 @@ func (r *ABTestingRegistry) GetForSession(ctx context.Context, key string, sessi
 		return "", err
 	}
 
-	// Select variant
-	variant, err := r.selector.SelectVariant(ctx, key, metadata.Variants, sessionID)
+	variant, err := r.assignVariant(ctx, key, sessionID, metadata.Variants)
 	if err != nil {
 		return "", err
 	}
+	variant = r.applyGuardrail(key, variant, metadata.Variants)
 
 	// Get prompt with selected variant
 	return r.underlying.GetWithVariant(ctx, key, variant, vars)
 }
 
+// assignVariant resolves the variant for (key, sessionID), honoring a sticky
+// prior assignment when an AssignmentStore is configured.
+func (r *ABTestingRegistry) assignVariant(ctx context.Context, key, sessionID string, variants []string) (string, error) {
+	if r.assignments == nil {
+		return r.selector.SelectVariant(ctx, key, variants, sessionID)
+	}
+
+	if existing, ok, err := r.assignments.Get(ctx, key, sessionID); err != nil {
+		return "", err
+	} else if ok && contains(variants, existing) {
+		return existing, nil
+	}
+
+	variant, err := r.selector.SelectVariant(ctx, key, variants, sessionID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.assignments.Put(ctx, key, sessionID, variant, r.expiresAt()); err != nil {
+		return "", err
+	}
+	return variant, nil
+}
+
+// ForceReassign clears any sticky assignment for (key, sessionID) and
+// re-derives and persists a fresh one from the selector. Use this to roll
+// out a new default cleanly without waiting for assignments to expire.
+func (r *ABTestingRegistry) ForceReassign(ctx context.Context, key, sessionID string) (string, error) {
+	if r.assignments == nil {
+		return "", fmt.Errorf("ForceReassign requires an AssignmentStore; use NewABTestingRegistryWithAssignments")
+	}
+
+	metadata, err := r.underlying.GetMetadata(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.assignments.Delete(ctx, key, sessionID); err != nil {
+		return "", err
+	}
+
+	variant, err := r.selector.SelectVariant(ctx, key, metadata.Variants, sessionID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.assignments.Put(ctx, key, sessionID, variant, r.expiresAt()); err != nil {
+		return "", err
+	}
+	return variant, nil
+}
+
+// WithMonitor attaches a VariantMonitor that GetForSession consults before
+// returning an experimental variant: if the variant's EMA error rate trips
+// cfg's guardrail against the first entry of metadata.Variants (treated as
+// control), the session is routed to control instead. Returns the registry
+// for chaining.
+func (r *ABTestingRegistry) WithMonitor(monitor *VariantMonitor, cfg GuardrailConfig) *ABTestingRegistry {
+	r.monitor = monitor
+	r.guardrail = cfg
+	return r
+}
+
+// applyGuardrail routes variant to control when the monitor judges it
+// unsafe to keep serving. variants must be non-empty; its first element is
+// treated as the control/default variant.
+func (r *ABTestingRegistry) applyGuardrail(key, variant string, variants []string) string {
+	if r.monitor == nil || len(variants) == 0 {
+		return variant
+	}
+	control := variants[0]
+	if r.monitor.CheckGuardrail(key, control, variant, r.guardrail) {
+		return control
+	}
+	return variant
+}
+
+func (r *ABTestingRegistry) expiresAt() time.Time {
+	if r.assignTTL <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(r.assignTTL)
+}
+
 // GetMetadata retrieves prompt metadata without the content.
 func (r *ABTestingRegistry) GetMetadata(ctx context.Context, key string) (*PromptMetadata, error) {
 	return r.underlying.GetMetadata(ctx, key)
@@ -275,7 +385,10 @@ func (r *ABTestingRegistry) Watch(ctx context.Context) (<-chan PromptUpdate, err
 // Context key for session ID
 type contextKey string
 
-const sessionIDKey contextKey = "session_id"
+const (
+	sessionIDKey  contextKey = "session_id"
+	attributesKey contextKey = "attributes"
+)
 
 // WithSessionID adds a session ID to the context.
 func WithSessionID(ctx context.Context, sessionID string) context.Context {
@@ -291,3 +404,19 @@ func GetSessionIDFromContext(ctx context.Context) string {
 	}
 	return "default"
 }
+
+// WithAttributes attaches arbitrary request context (tenant, user tier,
+// etc.) that an ExperimentSelector's segment targeting can match against.
+// Example: prompts.WithAttributes(ctx, map[string]any{"tenant": "acme"}).
+func WithAttributes(ctx context.Context, attributes map[string]any) context.Context {
+	return context.WithValue(ctx, attributesKey, attributes)
+}
+
+// GetAttributesFromContext retrieves the attributes set via WithAttributes.
+// Returns a non-nil empty map if none were set.
+func GetAttributesFromContext(ctx context.Context) map[string]any {
+	if attrs, ok := ctx.Value(attributesKey).(map[string]any); ok {
+		return attrs
+	}
+	return map[string]any{}
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package prompts
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// AssignmentStore persists sticky (key, sessionID) -> variant assignments so
+// a session keeps the variant it was first given even if the underlying
+// VariantSelector's output would otherwise change (e.g. because
+// PromptMetadata.Variants was edited and hash%len(variants) remapped).
+type AssignmentStore interface {
+	// Get returns the variant previously assigned to (key, sessionID), if
+	// any and not expired. ok is false if there is no live assignment.
+	Get(ctx context.Context, key, sessionID string) (variant string, ok bool, err error)
+
+	// Put records that sessionID is assigned to variant for key. A zero
+	// expiresAt means the assignment never expires.
+	Put(ctx context.Context, key, sessionID, variant string, expiresAt time.Time) error
+
+	// Delete removes any assignment for (key, sessionID), e.g. to force a
+	// fresh selection on the next GetForSession call.
+	Delete(ctx context.Context, key, sessionID string) error
+}
+
+// assignmentKey identifies one (prompt key, session ID) pair.
+type assignmentKey struct {
+	key       string
+	sessionID string
+}
+
+type assignmentEntry struct {
+	assignmentKey
+	variant   string
+	expiresAt time.Time
+}
+
+// InMemoryAssignmentStore is a process-local, LRU-bounded AssignmentStore.
+// Suitable for tests and single-instance deployments; use
+// postgres.AssignmentStore for a deployment that needs assignments to
+// survive restarts or be shared across instances.
+type InMemoryAssignmentStore struct {
+	mu       sync.Mutex
+	maxSize  int
+	entries  map[assignmentKey]*list.Element
+	eviction *list.List // front = most recently used
+}
+
+// NewInMemoryAssignmentStore creates an LRU-bounded assignment store holding
+// at most maxSize entries. maxSize <= 0 means unbounded.
+func NewInMemoryAssignmentStore(maxSize int) *InMemoryAssignmentStore {
+	return &InMemoryAssignmentStore{
+		maxSize:  maxSize,
+		entries:  make(map[assignmentKey]*list.Element),
+		eviction: list.New(),
+	}
+}
+
+// Get implements AssignmentStore.
+func (s *InMemoryAssignmentStore) Get(ctx context.Context, key, sessionID string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := assignmentKey{key: key, sessionID: sessionID}
+	elem, ok := s.entries[k]
+	if !ok {
+		return "", false, nil
+	}
+
+	entry := elem.Value.(*assignmentEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		s.eviction.Remove(elem)
+		delete(s.entries, k)
+		return "", false, nil
+	}
+
+	s.eviction.MoveToFront(elem)
+	return entry.variant, true, nil
+}
+
+// Put implements AssignmentStore.
+func (s *InMemoryAssignmentStore) Put(ctx context.Context, key, sessionID, variant string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := assignmentKey{key: key, sessionID: sessionID}
+	if elem, ok := s.entries[k]; ok {
+		entry := elem.Value.(*assignmentEntry)
+		entry.variant = variant
+		entry.expiresAt = expiresAt
+		s.eviction.MoveToFront(elem)
+		return nil
+	}
+
+	entry := &assignmentEntry{assignmentKey: k, variant: variant, expiresAt: expiresAt}
+	elem := s.eviction.PushFront(entry)
+	s.entries[k] = elem
+
+	if s.maxSize > 0 && len(s.entries) > s.maxSize {
+		oldest := s.eviction.Back()
+		if oldest != nil {
+			s.eviction.Remove(oldest)
+			delete(s.entries, oldest.Value.(*assignmentEntry).assignmentKey)
+		}
+	}
+
+	return nil
+}
+
+// Delete implements AssignmentStore.
+func (s *InMemoryAssignmentStore) Delete(ctx context.Context, key, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := assignmentKey{key: key, sessionID: sessionID}
+	if elem, ok := s.entries[k]; ok {
+		s.eviction.Remove(elem)
+		delete(s.entries, k)
+	}
+	return nil
+}
+
+// Compile-time check: InMemoryAssignmentStore implements AssignmentStore.
+var _ AssignmentStore = (*InMemoryAssignmentStore)(nil)
@@ -0,0 +1,248 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package prompts
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInMemoryAssignmentStore_PutGet(t *testing.T) {
+	store := NewInMemoryAssignmentStore(0)
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "test.key", "sess-123", "concise", time.Time{}); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+
+	variant, ok, err := store.Get(ctx, "test.key", "sess-123")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if !ok || variant != "concise" {
+		t.Errorf("Get() = (%q, %v), want (%q, true)", variant, ok, "concise")
+	}
+}
+
+func TestInMemoryAssignmentStore_GetMissing(t *testing.T) {
+	store := NewInMemoryAssignmentStore(0)
+	ctx := context.Background()
+
+	_, ok, err := store.Get(ctx, "test.key", "sess-missing")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if ok {
+		t.Error("Get() should report no assignment for an unknown session")
+	}
+}
+
+func TestInMemoryAssignmentStore_ExpiresTTL(t *testing.T) {
+	store := NewInMemoryAssignmentStore(0)
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "test.key", "sess-123", "concise", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+
+	_, ok, err := store.Get(ctx, "test.key", "sess-123")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if ok {
+		t.Error("Get() should not return an expired assignment")
+	}
+}
+
+func TestInMemoryAssignmentStore_Delete(t *testing.T) {
+	store := NewInMemoryAssignmentStore(0)
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "test.key", "sess-123", "concise", time.Time{}); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+	if err := store.Delete(ctx, "test.key", "sess-123"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+
+	_, ok, err := store.Get(ctx, "test.key", "sess-123")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if ok {
+		t.Error("Get() should report no assignment after Delete()")
+	}
+}
+
+func TestInMemoryAssignmentStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	store := NewInMemoryAssignmentStore(2)
+	ctx := context.Background()
+
+	_ = store.Put(ctx, "test.key", "sess-1", "a", time.Time{})
+	_ = store.Put(ctx, "test.key", "sess-2", "b", time.Time{})
+
+	// Touch sess-1 so it becomes most-recently-used.
+	if _, _, err := store.Get(ctx, "test.key", "sess-1"); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+
+	// Adding a third entry should evict sess-2 (least recently used), not sess-1.
+	_ = store.Put(ctx, "test.key", "sess-3", "c", time.Time{})
+
+	if _, ok, _ := store.Get(ctx, "test.key", "sess-1"); !ok {
+		t.Error("sess-1 should still be present (recently used)")
+	}
+	if _, ok, _ := store.Get(ctx, "test.key", "sess-2"); ok {
+		t.Error("sess-2 should have been evicted")
+	}
+	if _, ok, _ := store.Get(ctx, "test.key", "sess-3"); !ok {
+		t.Error("sess-3 should be present (just inserted)")
+	}
+}
+
+func TestABTestingRegistry_StickyAssignmentSurvivesVariantSetChange(t *testing.T) {
+	mock := newMockRegistry()
+	mock.addPrompt("test.prompt", "default", "Default")
+	mock.addPrompt("test.prompt", "concise", "Concise")
+	mock.addPrompt("test.prompt", "verbose", "Verbose")
+	mock.metadata["test.prompt"] = &PromptMetadata{
+		Key:      "test.prompt",
+		Variants: []string{"default", "concise", "verbose"},
+	}
+
+	// Force the initial selection to "concise".
+	selector := NewExplicitSelector("concise")
+	store := NewInMemoryAssignmentStore(0)
+	abRegistry := NewABTestingRegistryWithAssignments(mock, selector, store, 0)
+
+	ctx := context.Background()
+	first, err := abRegistry.GetForSession(ctx, "test.prompt", "sess-123", nil)
+	if err != nil {
+		t.Fatalf("GetForSession() failed: %v", err)
+	}
+	if first != "Concise" {
+		t.Fatalf("got %q, want %q", first, "Concise")
+	}
+
+	// Now the variant set is edited: "verbose" is removed and a new "terse"
+	// variant is added, simulating a live edit to PromptMetadata.Variants.
+	// "concise" (this session's sticky pick) is still present, so the
+	// sticky assignment should be reused without consulting the selector
+	// again, even though the set itself changed.
+	mock.addPrompt("test.prompt", "terse", "Terse")
+	mock.metadata["test.prompt"] = &PromptMetadata{
+		Key:      "test.prompt",
+		Variants: []string{"default", "concise", "terse"},
+	}
+
+	// Swap the selector so that a fresh (non-sticky) selection would land on
+	// a different variant ("terse"), making it possible to tell reuse apart
+	// from re-derivation below.
+	abRegistry.selector = NewExplicitSelector("terse")
+
+	second, err := abRegistry.GetForSession(ctx, "test.prompt", "sess-123", nil)
+	if err != nil {
+		t.Fatalf("GetForSession() second call failed: %v", err)
+	}
+	if second != first {
+		t.Errorf("sticky session got a different variant: %q vs %q", second, first)
+	}
+}
+
+func TestABTestingRegistry_ReassignsWhenStickyVariantRemoved(t *testing.T) {
+	mock := newMockRegistry()
+	mock.addPrompt("test.prompt", "default", "Default")
+	mock.addPrompt("test.prompt", "concise", "Concise")
+	mock.metadata["test.prompt"] = &PromptMetadata{
+		Key:      "test.prompt",
+		Variants: []string{"default", "concise"},
+	}
+
+	selector := NewExplicitSelector("concise")
+	store := NewInMemoryAssignmentStore(0)
+	abRegistry := NewABTestingRegistryWithAssignments(mock, selector, store, 0)
+
+	ctx := context.Background()
+	if _, err := abRegistry.GetForSession(ctx, "test.prompt", "sess-123", nil); err != nil {
+		t.Fatalf("GetForSession() failed: %v", err)
+	}
+
+	// "concise" is removed from the variant set and the selector is swapped
+	// to one that would only ever pick "default" now.
+	mock.metadata["test.prompt"] = &PromptMetadata{
+		Key:      "test.prompt",
+		Variants: []string{"default"},
+	}
+	abRegistry.selector = NewExplicitSelector("default")
+
+	result, err := abRegistry.GetForSession(ctx, "test.prompt", "sess-123", nil)
+	if err != nil {
+		t.Fatalf("GetForSession() after variant removal failed: %v", err)
+	}
+	if result != "Default" {
+		t.Errorf("got %q, want %q after sticky variant was removed", result, "Default")
+	}
+}
+
+func TestABTestingRegistry_ForceReassign(t *testing.T) {
+	mock := newMockRegistry()
+	mock.addPrompt("test.prompt", "default", "Default")
+	mock.addPrompt("test.prompt", "concise", "Concise")
+	mock.metadata["test.prompt"] = &PromptMetadata{
+		Key:      "test.prompt",
+		Variants: []string{"default", "concise"},
+	}
+
+	selector := NewExplicitSelector("default")
+	store := NewInMemoryAssignmentStore(0)
+	abRegistry := NewABTestingRegistryWithAssignments(mock, selector, store, 0)
+
+	ctx := context.Background()
+	if _, err := abRegistry.GetForSession(ctx, "test.prompt", "sess-123", nil); err != nil {
+		t.Fatalf("GetForSession() failed: %v", err)
+	}
+
+	abRegistry.selector = NewExplicitSelector("concise")
+	variant, err := abRegistry.ForceReassign(ctx, "test.prompt", "sess-123")
+	if err != nil {
+		t.Fatalf("ForceReassign() failed: %v", err)
+	}
+	if variant != "concise" {
+		t.Errorf("ForceReassign() = %q, want %q", variant, "concise")
+	}
+
+	result, err := abRegistry.GetForSession(ctx, "test.prompt", "sess-123", nil)
+	if err != nil {
+		t.Fatalf("GetForSession() after ForceReassign() failed: %v", err)
+	}
+	if result != "Concise" {
+		t.Errorf("got %q, want %q after ForceReassign()", result, "Concise")
+	}
+}
+
+func TestABTestingRegistry_ForceReassignWithoutStoreErrors(t *testing.T) {
+	mock := newMockRegistry()
+	mock.addPrompt("test.prompt", "default", "Default")
+	mock.metadata["test.prompt"] = &PromptMetadata{
+		Key:      "test.prompt",
+		Variants: []string{"default"},
+	}
+
+	abRegistry := NewABTestingRegistry(mock, NewExplicitSelector("default"))
+
+	if _, err := abRegistry.ForceReassign(context.Background(), "test.prompt", "sess-123"); err == nil {
+		t.Error("ForceReassign() should fail without an AssignmentStore")
+	}
+}
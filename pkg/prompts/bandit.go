@@ -0,0 +1,285 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// OutcomeReporter lets callers close the feedback loop on a variant
+// selection made earlier (e.g. after a session's latency, rating, or task
+// success is known). Selectors that don't learn from outcomes (Explicit,
+// Hash, Random, Weighted) don't need to implement it.
+type OutcomeReporter interface {
+	// ReportOutcome records a reward in [0, 1] for a (key, variant) arm
+	// previously returned by SelectVariant for sessionID.
+	ReportOutcome(ctx context.Context, key string, variant string, sessionID string, reward float64) error
+}
+
+// ArmStats holds the Beta(alpha, beta) posterior for a single (key, variant)
+// arm. Alpha/Beta start at 1 (uniform prior) and are updated via
+// alpha += reward, beta += 1 - reward on each reported outcome.
+type ArmStats struct {
+	Alpha float64
+	Beta  float64
+}
+
+// Pulls returns the number of outcomes folded into this arm so far.
+func (a ArmStats) Pulls() float64 {
+	return a.Alpha + a.Beta - 2
+}
+
+// Mean returns the posterior mean reward for this arm.
+func (a ArmStats) Mean() float64 {
+	return a.Alpha / (a.Alpha + a.Beta)
+}
+
+// BanditStore persists per-arm Beta posterior state so experiments survive
+// process restarts. Implementations must be safe for concurrent use.
+type BanditStore interface {
+	// Get returns the current stats for (key, variant), or the uniform
+	// prior (Alpha: 1, Beta: 1) if the arm has never been pulled.
+	Get(ctx context.Context, key, variant string) (ArmStats, error)
+
+	// Update applies a single outcome to (key, variant) and returns the
+	// resulting stats.
+	Update(ctx context.Context, key, variant string, reward float64) (ArmStats, error)
+}
+
+// InMemoryBanditStore is a process-local BanditStore backed by a map.
+// Suitable for tests and single-instance deployments.
+type InMemoryBanditStore struct {
+	mu   sync.Mutex
+	arms map[string]ArmStats
+}
+
+// NewInMemoryBanditStore creates an empty in-memory bandit store.
+func NewInMemoryBanditStore() *InMemoryBanditStore {
+	return &InMemoryBanditStore{
+		arms: make(map[string]ArmStats),
+	}
+}
+
+func banditArmKey(key, variant string) string {
+	return key + "\x00" + variant
+}
+
+// Get implements BanditStore.
+func (s *InMemoryBanditStore) Get(ctx context.Context, key, variant string) (ArmStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.arms[banditArmKey(key, variant)]
+	if !ok {
+		return ArmStats{Alpha: 1, Beta: 1}, nil
+	}
+	return stats, nil
+}
+
+// Update implements BanditStore.
+func (s *InMemoryBanditStore) Update(ctx context.Context, key, variant string, reward float64) (ArmStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := banditArmKey(key, variant)
+	stats, ok := s.arms[k]
+	if !ok {
+		stats = ArmStats{Alpha: 1, Beta: 1}
+	}
+	stats.Alpha += reward
+	stats.Beta += 1 - reward
+	s.arms[k] = stats
+	return stats, nil
+}
+
+// BanditSelector is a VariantSelector and OutcomeReporter implementing
+// Thompson sampling over a Beta(alpha, beta) posterior per (key, variant)
+// arm. On selection it draws a sample from each candidate variant's
+// posterior and picks the argmax; on ReportOutcome it folds the reward into
+// the corresponding arm.
+//
+// Example:
+//
+//	store := prompts.NewInMemoryBanditStore()
+//	bandit := prompts.NewBanditSelector(store, prompts.BanditConfig{
+//	    Epsilon:   0.1,
+//	    MinPulls:  20,
+//	})
+//	abRegistry := prompts.NewABTestingRegistry(fileRegistry, bandit)
+//	// ... later, once the outcome is known:
+//	_ = bandit.ReportOutcome(ctx, "agent.system", variant, sessionID, reward)
+type BanditSelector struct {
+	store  BanditStore
+	rng    *rand.Rand
+	mu     sync.Mutex // guards rng, which is not safe for concurrent use
+	config BanditConfig
+}
+
+// BanditConfig tunes the exploration behavior of a BanditSelector.
+type BanditConfig struct {
+	// Epsilon is the probability of falling back to uniform random
+	// selection while the candidate arms' combined pulls are below MinPulls. Zero
+	// disables the epsilon-greedy fallback (pure Thompson sampling from
+	// the first pull).
+	Epsilon float64
+
+	// MinPulls is the total-pulls threshold under which the epsilon-greedy
+	// fallback applies.
+	MinPulls float64
+
+	// Frozen stops learning: SelectVariant picks the argmax of posterior
+	// means (ignoring Epsilon) instead of sampling, and ReportOutcome
+	// becomes a no-op. Use this once an experiment has shipped and you
+	// want to treat the current posterior as a fixed weighting.
+	Frozen bool
+
+	// Seed seeds the selector's RNG. Zero uses a random seed.
+	Seed int64
+}
+
+// NewBanditSelector creates a Thompson-sampling selector backed by store.
+func NewBanditSelector(store BanditStore, config BanditConfig) *BanditSelector {
+	var rng *rand.Rand
+	if config.Seed == 0 {
+		// #nosec G404 -- A/B testing statistical distribution doesn't need crypto randomness
+		rng = rand.New(rand.NewSource(rand.Int63()))
+	} else {
+		// #nosec G404 -- A/B testing statistical distribution doesn't need crypto randomness
+		rng = rand.New(rand.NewSource(config.Seed))
+	}
+	return &BanditSelector{
+		store:  store,
+		rng:    rng,
+		config: config,
+	}
+}
+
+// SelectVariant implements VariantSelector.
+func (s *BanditSelector) SelectVariant(ctx context.Context, key string, variants []string, sessionID string) (string, error) {
+	if len(variants) == 0 {
+		return "", fmt.Errorf("no variants available")
+	}
+
+	arms := make([]ArmStats, len(variants))
+	totalPulls := 0.0
+	for i, v := range variants {
+		stats, err := s.store.Get(ctx, key, v)
+		if err != nil {
+			return "", fmt.Errorf("failed to load arm stats for %q: %w", v, err)
+		}
+		arms[i] = stats
+		totalPulls += stats.Pulls()
+	}
+
+	if !s.config.Frozen && s.config.Epsilon > 0 && totalPulls < s.config.MinPulls {
+		if s.randFloat64() < s.config.Epsilon {
+			return variants[s.randIntn(len(variants))], nil
+		}
+	}
+
+	best := 0
+	bestValue := math.Inf(-1)
+	for i, stats := range arms {
+		var value float64
+		if s.config.Frozen {
+			value = stats.Mean()
+		} else {
+			value = s.sampleBeta(stats.Alpha, stats.Beta)
+		}
+		if value > bestValue {
+			bestValue = value
+			best = i
+		}
+	}
+
+	return variants[best], nil
+}
+
+// ReportOutcome implements OutcomeReporter by folding reward into the
+// (key, variant) arm. It is a no-op in frozen mode.
+func (s *BanditSelector) ReportOutcome(ctx context.Context, key, variant, sessionID string, reward float64) error {
+	if s.config.Frozen {
+		return nil
+	}
+	if reward < 0 || reward > 1 {
+		return fmt.Errorf("reward must be in [0, 1], got %f", reward)
+	}
+	_, err := s.store.Update(ctx, key, variant, reward)
+	return err
+}
+
+// sampleBeta draws a sample from Beta(alpha, beta) using the standard
+// ratio-of-Gammas construction: X ~ Gamma(alpha), Y ~ Gamma(beta),
+// X/(X+Y) ~ Beta(alpha, beta).
+func (s *BanditSelector) sampleBeta(alpha, beta float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g1 := sampleGamma(s.rng, alpha)
+	g2 := sampleGamma(s.rng, beta)
+	if g1+g2 == 0 {
+		return 0.5
+	}
+	return g1 / (g1 + g2)
+}
+
+func (s *BanditSelector) randFloat64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Float64()
+}
+
+func (s *BanditSelector) randIntn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Intn(n)
+}
+
+// sampleGamma draws a sample from Gamma(shape, 1) using the
+// Marsaglia-Tsang method (shape >= 1), falling back to the boost-by-one
+// transform for shape in (0, 1).
+func sampleGamma(rng *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		// #nosec G404 -- statistical sampling, not security sensitive
+		u := rng.Float64()
+		return sampleGamma(rng, shape+1) * math.Pow(u, 1/shape)
+	}
+
+	d := shape - 1.0/3.0
+	c := 1.0 / math.Sqrt(9*d)
+
+	for {
+		var x, v float64
+		for {
+			x = rng.NormFloat64()
+			v = 1 + c*x
+			if v > 0 {
+				break
+			}
+		}
+		v = v * v * v
+		u := rng.Float64()
+
+		if u < 1-0.0331*x*x*x*x {
+			return d * v
+		}
+		if math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package prompts
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInMemoryBanditStore_DefaultIsUniformPrior(t *testing.T) {
+	store := NewInMemoryBanditStore()
+	ctx := context.Background()
+
+	stats, err := store.Get(ctx, "test.key", "default")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if stats.Alpha != 1 || stats.Beta != 1 {
+		t.Errorf("Get() = %+v, want uniform prior {Alpha: 1, Beta: 1}", stats)
+	}
+}
+
+func TestInMemoryBanditStore_UpdateAccumulates(t *testing.T) {
+	store := NewInMemoryBanditStore()
+	ctx := context.Background()
+
+	stats, err := store.Update(ctx, "test.key", "variant-a", 1.0)
+	if err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	if stats.Alpha != 2 || stats.Beta != 1 {
+		t.Errorf("Update() after one success = %+v, want {Alpha: 2, Beta: 1}", stats)
+	}
+
+	stats, err = store.Update(ctx, "test.key", "variant-a", 0.0)
+	if err != nil {
+		t.Fatalf("second Update() failed: %v", err)
+	}
+	if stats.Alpha != 2 || stats.Beta != 2 {
+		t.Errorf("Update() after one success and one failure = %+v, want {Alpha: 2, Beta: 2}", stats)
+	}
+}
+
+func TestBanditSelector_SelectsKnownVariant(t *testing.T) {
+	store := NewInMemoryBanditStore()
+	selector := NewBanditSelector(store, BanditConfig{Seed: 1})
+	ctx := context.Background()
+	variants := []string{"default", "experimental"}
+
+	variant, err := selector.SelectVariant(ctx, "test.key", variants, "sess-123")
+	if err != nil {
+		t.Fatalf("SelectVariant() failed: %v", err)
+	}
+	if variant != "default" && variant != "experimental" {
+		t.Errorf("SelectVariant() = %q, want one of %v", variant, variants)
+	}
+}
+
+func TestBanditSelector_NoVariants(t *testing.T) {
+	store := NewInMemoryBanditStore()
+	selector := NewBanditSelector(store, BanditConfig{Seed: 1})
+	ctx := context.Background()
+
+	if _, err := selector.SelectVariant(ctx, "test.key", nil, "sess-123"); err == nil {
+		t.Error("SelectVariant() should fail with no variants")
+	}
+}
+
+func TestBanditSelector_ReportOutcomeUpdatesStore(t *testing.T) {
+	store := NewInMemoryBanditStore()
+	selector := NewBanditSelector(store, BanditConfig{Seed: 1})
+	ctx := context.Background()
+
+	if err := selector.ReportOutcome(ctx, "test.key", "experimental", "sess-123", 1.0); err != nil {
+		t.Fatalf("ReportOutcome() failed: %v", err)
+	}
+
+	stats, err := store.Get(ctx, "test.key", "experimental")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if stats.Alpha != 2 || stats.Beta != 1 {
+		t.Errorf("Get() after ReportOutcome = %+v, want {Alpha: 2, Beta: 1}", stats)
+	}
+}
+
+func TestBanditSelector_ReportOutcomeRejectsOutOfRangeReward(t *testing.T) {
+	store := NewInMemoryBanditStore()
+	selector := NewBanditSelector(store, BanditConfig{Seed: 1})
+	ctx := context.Background()
+
+	if err := selector.ReportOutcome(ctx, "test.key", "default", "sess-123", 1.5); err == nil {
+		t.Error("ReportOutcome() should reject reward outside [0, 1]")
+	}
+}
+
+func TestBanditSelector_FrozenStopsLearning(t *testing.T) {
+	store := NewInMemoryBanditStore()
+	selector := NewBanditSelector(store, BanditConfig{Seed: 1, Frozen: true})
+	ctx := context.Background()
+
+	if err := selector.ReportOutcome(ctx, "test.key", "default", "sess-123", 1.0); err != nil {
+		t.Fatalf("ReportOutcome() failed: %v", err)
+	}
+
+	stats, err := store.Get(ctx, "test.key", "default")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if stats.Alpha != 1 || stats.Beta != 1 {
+		t.Errorf("Get() after frozen ReportOutcome = %+v, want unchanged prior {Alpha: 1, Beta: 1}", stats)
+	}
+}
+
+func TestBanditSelector_FrozenPicksHighestMean(t *testing.T) {
+	store := NewInMemoryBanditStore()
+	// Bias "experimental" heavily toward success before freezing.
+	for i := 0; i < 20; i++ {
+		if _, err := store.Update(context.Background(), "test.key", "experimental", 1.0); err != nil {
+			t.Fatalf("Update() failed: %v", err)
+		}
+	}
+
+	selector := NewBanditSelector(store, BanditConfig{Seed: 1, Frozen: true})
+	ctx := context.Background()
+	variants := []string{"default", "experimental"}
+
+	variant, err := selector.SelectVariant(ctx, "test.key", variants, "sess-123")
+	if err != nil {
+		t.Fatalf("SelectVariant() failed: %v", err)
+	}
+	if variant != "experimental" {
+		t.Errorf("SelectVariant() in frozen mode = %q, want %q", variant, "experimental")
+	}
+}
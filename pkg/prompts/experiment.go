@@ -0,0 +1,318 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+// Experiment describes a traffic-ramped, segment-targeted rollout of a
+// prompt variant experiment for a given key. Sessions for that key that
+// don't match the experiment (outside the ramp, outside the segment, or the
+// experiment is killed/expired) get the control variant unchanged; other
+// keys are not affected by it.
+type Experiment struct {
+	// Key is the prompt key this experiment applies to.
+	Key string
+
+	// Control is the variant returned for sessions not bucketed into the
+	// experiment.
+	Control string
+
+	// TrafficPercent is the percentage (0-100) of matching sessions
+	// bucketed into the experiment; the rest get Control.
+	TrafficPercent float64
+
+	// Start and End bound when the experiment is active. A zero Start
+	// means "always started"; a zero End means "never ends".
+	Start time.Time
+	End   time.Time
+
+	// KillSwitch short-circuits every session to Control, regardless of
+	// ramp or segment, without needing to remove the experiment.
+	KillSwitch bool
+
+	// Segments are predicate expressions over context attributes (set via
+	// WithAttributes) that must ALL match for a session to be eligible.
+	// Supported forms:
+	//   attr == "value"
+	//   attr in {"value1", "value2"}
+	// An empty Segments list matches every session.
+	Segments []string
+
+	// Selector picks the variant for sessions bucketed into the
+	// experiment (weighted, bandit, hash, etc).
+	Selector VariantSelector
+}
+
+// active reports whether the experiment is currently live, ignoring ramp
+// and segment matching.
+func (e *Experiment) active(now time.Time) bool {
+	if e.KillSwitch {
+		return false
+	}
+	if !e.Start.IsZero() && now.Before(e.Start) {
+		return false
+	}
+	if !e.End.IsZero() && now.After(e.End) {
+		return false
+	}
+	return true
+}
+
+// matchesSegments reports whether attrs satisfies every segment predicate.
+func (e *Experiment) matchesSegments(attrs map[string]any) (bool, error) {
+	for _, expr := range e.Segments {
+		ok, err := evaluateSegment(expr, attrs)
+		if err != nil {
+			return false, fmt.Errorf("experiment %q: invalid segment %q: %w", e.Key, expr, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// inRamp deterministically buckets sessionID into TrafficPercent of traffic
+// for this experiment, using the same hash-then-mod approach as HashSelector
+// so a given session's bucket is stable across calls.
+func (e *Experiment) inRamp(sessionID string) bool {
+	if e.TrafficPercent >= 100 {
+		return true
+	}
+	if e.TrafficPercent <= 0 {
+		return false
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(sessionID))
+	h.Write([]byte(e.Key))
+	h.Write([]byte("experiment-ramp"))
+	bucket := h.Sum64() % 100
+	return float64(bucket) < e.TrafficPercent
+}
+
+// ExperimentSelector evaluates a set of Experiments to decide whether a
+// session should be routed to an experiment's inner selector or to the
+// control variant, then records an assignment event via the module's
+// observability hooks.
+type ExperimentSelector struct {
+	experiments []Experiment
+	fallback    VariantSelector
+	tracer      observability.Tracer
+}
+
+// NewExperimentSelector creates a selector that evaluates experiments in
+// order and uses fallback for keys no experiment covers.
+func NewExperimentSelector(experiments []Experiment, fallback VariantSelector) *ExperimentSelector {
+	return &ExperimentSelector{
+		experiments: experiments,
+		fallback:    fallback,
+		tracer:      observability.NewNoOpTracer(),
+	}
+}
+
+// WithTracer sets the observability tracer used to emit assignment events.
+// Returns the selector for chaining.
+func (s *ExperimentSelector) WithTracer(tracer observability.Tracer) *ExperimentSelector {
+	if tracer != nil {
+		s.tracer = tracer
+	}
+	return s
+}
+
+// SelectVariant implements VariantSelector.
+func (s *ExperimentSelector) SelectVariant(ctx context.Context, key string, variants []string, sessionID string) (string, error) {
+	for i := range s.experiments {
+		exp := &s.experiments[i]
+		if exp.Key != key {
+			continue
+		}
+
+		if exp.KillSwitch || !exp.active(time.Now()) {
+			s.recordAssignment(ctx, exp, sessionID, exp.Control, "killed_or_inactive")
+			return exp.Control, nil
+		}
+
+		attrs := GetAttributesFromContext(ctx)
+		matched, err := exp.matchesSegments(attrs)
+		if err != nil {
+			return "", err
+		}
+		if !matched {
+			s.recordAssignment(ctx, exp, sessionID, exp.Control, "segment_mismatch")
+			return exp.Control, nil
+		}
+
+		if !exp.inRamp(sessionID) {
+			s.recordAssignment(ctx, exp, sessionID, exp.Control, "outside_ramp")
+			return exp.Control, nil
+		}
+
+		selector := exp.Selector
+		if selector == nil {
+			selector = s.fallback
+		}
+		variant, err := selector.SelectVariant(ctx, key, variants, sessionID)
+		if err != nil {
+			return "", err
+		}
+		s.recordAssignment(ctx, exp, sessionID, variant, "in_experiment")
+		return variant, nil
+	}
+
+	if s.fallback == nil {
+		return "", fmt.Errorf("no experiment covers key %q and no fallback selector is configured", key)
+	}
+	return s.fallback.SelectVariant(ctx, key, variants, sessionID)
+}
+
+// recordAssignment emits a structured event so downstream analytics can
+// join exposures (this event) with outcomes (reported separately, e.g. via
+// OutcomeReporter).
+func (s *ExperimentSelector) recordAssignment(ctx context.Context, exp *Experiment, sessionID, variant, reason string) {
+	s.tracer.RecordEvent(ctx, "prompts.experiment.assignment", map[string]interface{}{
+		"key":        exp.Key,
+		"variant":    variant,
+		"session_id": sessionID,
+		"reason":     reason,
+	})
+}
+
+// experimentFile is the YAML shape for one experiment, loaded via
+// LoadExperimentsFromYAML. The inner selector is built from Selector/
+// Weights/Control rather than deserialized directly, since VariantSelector
+// implementations aren't generally YAML-serializable.
+type experimentFile struct {
+	Key            string         `yaml:"key"`
+	Control        string         `yaml:"control"`
+	TrafficPercent float64        `yaml:"traffic_percent"`
+	Start          time.Time      `yaml:"start"`
+	End            time.Time      `yaml:"end"`
+	KillSwitch     bool           `yaml:"kill_switch"`
+	Segments       []string       `yaml:"segments"`
+	Selector       string         `yaml:"selector"` // "hash", "random", or "weighted"
+	Weights        map[string]int `yaml:"weights"`  // used when selector is "weighted"
+}
+
+// LoadExperimentsFromYAML loads experiment definitions from a YAML file
+// living next to prompt files in a FileRegistry directory. The file holds a
+// top-level "experiments" list; see experimentFile for the per-entry shape.
+func LoadExperimentsFromYAML(path string) ([]Experiment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read experiments file: %w", err)
+	}
+
+	var doc struct {
+		Experiments []experimentFile `yaml:"experiments"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse experiments file: %w", err)
+	}
+
+	experiments := make([]Experiment, 0, len(doc.Experiments))
+	for _, ef := range doc.Experiments {
+		var selector VariantSelector
+		switch ef.Selector {
+		case "", "hash":
+			selector = NewHashSelector()
+		case "random":
+			selector = NewRandomSelector(0)
+		case "weighted":
+			selector = NewWeightedSelector(ef.Weights, 0)
+		default:
+			return nil, fmt.Errorf("experiment %q: unknown selector %q", ef.Key, ef.Selector)
+		}
+
+		experiments = append(experiments, Experiment{
+			Key:            ef.Key,
+			Control:        ef.Control,
+			TrafficPercent: ef.TrafficPercent,
+			Start:          ef.Start,
+			End:            ef.End,
+			KillSwitch:     ef.KillSwitch,
+			Segments:       ef.Segments,
+			Selector:       selector,
+		})
+	}
+
+	return experiments, nil
+}
+
+// evaluateSegment evaluates a single segment predicate of the form
+// `attr == "value"` or `attr in {"value1", "value2"}` against attrs.
+// Attribute values are compared via fmt.Sprint, so numeric/bool/string
+// attributes all work with quoted string literals in the expression.
+func evaluateSegment(expr string, attrs map[string]any) (bool, error) {
+	expr = strings.TrimSpace(expr)
+
+	if idx := strings.Index(expr, "=="); idx != -1 {
+		attr := strings.TrimSpace(expr[:idx])
+		literal := strings.TrimSpace(expr[idx+2:])
+		value, err := unquote(literal)
+		if err != nil {
+			return false, err
+		}
+		return attrString(attrs, attr) == value, nil
+	}
+
+	if idx := strings.Index(expr, " in "); idx != -1 {
+		attr := strings.TrimSpace(expr[:idx])
+		set := strings.TrimSpace(expr[idx+len(" in "):])
+		set = strings.TrimPrefix(set, "{")
+		set = strings.TrimSuffix(set, "}")
+
+		current := attrString(attrs, attr)
+		for _, raw := range strings.Split(set, ",") {
+			value, err := unquote(strings.TrimSpace(raw))
+			if err != nil {
+				return false, err
+			}
+			if current == value {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	return false, fmt.Errorf("unsupported expression (expected \"attr == ...\" or \"attr in {...}\")")
+}
+
+// attrString returns attrs[name] formatted as a string, or "" if absent.
+func attrString(attrs map[string]any, name string) string {
+	v, ok := attrs[name]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// unquote strips a single layer of matching double quotes from s.
+func unquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("expected a double-quoted string literal, got %q", s)
+	}
+	return s[1 : len(s)-1], nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package prompts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExperimentSelector_KillSwitchReturnsControl(t *testing.T) {
+	experiments := []Experiment{
+		{
+			Key:            "test.key",
+			Control:        "default",
+			TrafficPercent: 100,
+			KillSwitch:     true,
+			Selector:       NewExplicitSelector("experimental"),
+		},
+	}
+	selector := NewExperimentSelector(experiments, NewExplicitSelector("default"))
+
+	variant, err := selector.SelectVariant(context.Background(), "test.key", []string{"default", "experimental"}, "sess-123")
+	if err != nil {
+		t.Fatalf("SelectVariant() failed: %v", err)
+	}
+	if variant != "default" {
+		t.Errorf("SelectVariant() = %q, want %q (kill switch should short-circuit to control)", variant, "default")
+	}
+}
+
+func TestExperimentSelector_OutsideRampReturnsControl(t *testing.T) {
+	experiments := []Experiment{
+		{
+			Key:            "test.key",
+			Control:        "default",
+			TrafficPercent: 0,
+			Selector:       NewExplicitSelector("experimental"),
+		},
+	}
+	selector := NewExperimentSelector(experiments, NewExplicitSelector("default"))
+
+	variant, err := selector.SelectVariant(context.Background(), "test.key", []string{"default", "experimental"}, "sess-123")
+	if err != nil {
+		t.Fatalf("SelectVariant() failed: %v", err)
+	}
+	if variant != "default" {
+		t.Errorf("SelectVariant() = %q, want %q (0%% ramp should never bucket a session in)", variant, "default")
+	}
+}
+
+func TestExperimentSelector_FullRampDelegatesToInnerSelector(t *testing.T) {
+	experiments := []Experiment{
+		{
+			Key:            "test.key",
+			Control:        "default",
+			TrafficPercent: 100,
+			Selector:       NewExplicitSelector("experimental"),
+		},
+	}
+	selector := NewExperimentSelector(experiments, NewExplicitSelector("default"))
+
+	variant, err := selector.SelectVariant(context.Background(), "test.key", []string{"default", "experimental"}, "sess-123")
+	if err != nil {
+		t.Fatalf("SelectVariant() failed: %v", err)
+	}
+	if variant != "experimental" {
+		t.Errorf("SelectVariant() = %q, want %q (100%% ramp should always bucket a session in)", variant, "experimental")
+	}
+}
+
+func TestExperimentSelector_SegmentMismatchReturnsControl(t *testing.T) {
+	experiments := []Experiment{
+		{
+			Key:            "test.key",
+			Control:        "default",
+			TrafficPercent: 100,
+			Segments:       []string{`tenant == "acme"`},
+			Selector:       NewExplicitSelector("experimental"),
+		},
+	}
+	selector := NewExperimentSelector(experiments, NewExplicitSelector("default"))
+
+	ctx := WithAttributes(context.Background(), map[string]any{"tenant": "other-corp"})
+	variant, err := selector.SelectVariant(ctx, "test.key", []string{"default", "experimental"}, "sess-123")
+	if err != nil {
+		t.Fatalf("SelectVariant() failed: %v", err)
+	}
+	if variant != "default" {
+		t.Errorf("SelectVariant() = %q, want %q (segment should not match)", variant, "default")
+	}
+}
+
+func TestExperimentSelector_SegmentMatchDelegates(t *testing.T) {
+	experiments := []Experiment{
+		{
+			Key:            "test.key",
+			Control:        "default",
+			TrafficPercent: 100,
+			Segments:       []string{`tenant == "acme"`, `user.tier in {"pro", "enterprise"}`},
+			Selector:       NewExplicitSelector("experimental"),
+		},
+	}
+	selector := NewExperimentSelector(experiments, NewExplicitSelector("default"))
+
+	ctx := WithAttributes(context.Background(), map[string]any{"tenant": "acme", "user.tier": "enterprise"})
+	variant, err := selector.SelectVariant(ctx, "test.key", []string{"default", "experimental"}, "sess-123")
+	if err != nil {
+		t.Fatalf("SelectVariant() failed: %v", err)
+	}
+	if variant != "experimental" {
+		t.Errorf("SelectVariant() = %q, want %q (all segments should match)", variant, "experimental")
+	}
+}
+
+func TestExperimentSelector_OutsideTimeWindowReturnsControl(t *testing.T) {
+	experiments := []Experiment{
+		{
+			Key:            "test.key",
+			Control:        "default",
+			TrafficPercent: 100,
+			End:            time.Now().Add(-time.Hour),
+			Selector:       NewExplicitSelector("experimental"),
+		},
+	}
+	selector := NewExperimentSelector(experiments, NewExplicitSelector("default"))
+
+	variant, err := selector.SelectVariant(context.Background(), "test.key", []string{"default", "experimental"}, "sess-123")
+	if err != nil {
+		t.Fatalf("SelectVariant() failed: %v", err)
+	}
+	if variant != "default" {
+		t.Errorf("SelectVariant() = %q, want %q (expired experiment should return control)", variant, "default")
+	}
+}
+
+func TestExperimentSelector_UncoveredKeyUsesFallback(t *testing.T) {
+	selector := NewExperimentSelector(nil, NewExplicitSelector("default"))
+
+	variant, err := selector.SelectVariant(context.Background(), "other.key", []string{"default"}, "sess-123")
+	if err != nil {
+		t.Fatalf("SelectVariant() failed: %v", err)
+	}
+	if variant != "default" {
+		t.Errorf("SelectVariant() = %q, want %q", variant, "default")
+	}
+}
+
+func TestLoadExperimentsFromYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "experiments.yaml")
+	content := `
+experiments:
+  - key: agent.system
+    control: default
+    traffic_percent: 50
+    kill_switch: false
+    segments:
+      - tenant == "acme"
+    selector: weighted
+    weights:
+      default: 1
+      concise: 1
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write experiments file: %v", err)
+	}
+
+	experiments, err := LoadExperimentsFromYAML(path)
+	if err != nil {
+		t.Fatalf("LoadExperimentsFromYAML() failed: %v", err)
+	}
+	if len(experiments) != 1 {
+		t.Fatalf("LoadExperimentsFromYAML() returned %d experiments, want 1", len(experiments))
+	}
+
+	exp := experiments[0]
+	if exp.Key != "agent.system" || exp.Control != "default" || exp.TrafficPercent != 50 {
+		t.Errorf("unexpected experiment: %+v", exp)
+	}
+	if exp.Selector == nil {
+		t.Error("expected a non-nil Selector built from the weighted config")
+	}
+}
+
+func TestLoadExperimentsFromYAML_UnknownSelector(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "experiments.yaml")
+	content := `
+experiments:
+  - key: agent.system
+    control: default
+    selector: bogus
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write experiments file: %v", err)
+	}
+
+	if _, err := LoadExperimentsFromYAML(path); err == nil {
+		t.Error("LoadExperimentsFromYAML() should fail for an unknown selector kind")
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package prompts
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// ArmStatus is a snapshot of a (key, variant) arm's exponential moving
+// averages, returned by VariantMonitor.Status for dashboards.
+type ArmStatus struct {
+	Samples    int64
+	Since      time.Time
+	LatencyEMA float64
+	ErrorEMA   float64
+	RewardEMA  float64
+}
+
+// monitorArm holds the running EMA state for one (key, variant) pair.
+type monitorArm struct {
+	samples    int64
+	start      time.Time
+	lastSample time.Time
+	latencyEMA float64
+	errorEMA   float64
+	rewardEMA  float64
+	tripped    bool
+}
+
+// armKey identifies one monitored arm.
+type armKey struct {
+	key     string
+	variant string
+}
+
+// GuardrailConfig controls when VariantMonitor trips an experimental variant
+// back to control.
+type GuardrailConfig struct {
+	// ErrorRateMultiplier is how many times control's EMA error rate an
+	// experimental variant's EMA error rate must exceed to trip the
+	// guardrail (e.g. 2.0 for "2x control").
+	ErrorRateMultiplier float64
+
+	// MinSamples is the minimum sample count both the control and the
+	// candidate arm must have before the guardrail is evaluated; below
+	// this, the arm is "new and noisy" and is left alone.
+	MinSamples int64
+
+	// MinErrorRate is an absolute floor on the variant's EMA error rate
+	// below which the guardrail never trips, even if it exceeds
+	// ErrorRateMultiplier times control's. Without this, a clean control
+	// (EMA error rate 0) makes the relative threshold 0 too, so a single
+	// experimental error would trip the guardrail regardless of
+	// ErrorRateMultiplier.
+	MinErrorRate float64
+}
+
+// VariantMonitor maintains an exponential moving average of latency, error
+// rate, and a user-supplied reward, per (key, variant) arm. Samples may
+// arrive at arbitrary wall-clock times (not fixed ticks): the smoothing
+// factor is derived from the elapsed time since the arm's last sample,
+// a = 1 - exp(-dt/tau), so infrequent arms don't get over-weighted by the
+// fixed-interval assumption a naive recurrence would make.
+type VariantMonitor struct {
+	mu     sync.Mutex
+	tau    time.Duration
+	arms   map[armKey]*monitorArm
+	onTrip func(key, variant, reason string)
+}
+
+// NewVariantMonitor creates a monitor with smoothing time constant tau
+// (larger tau means slower-reacting, lower-variance estimates).
+func NewVariantMonitor(tau time.Duration) *VariantMonitor {
+	return &VariantMonitor{
+		tau:  tau,
+		arms: make(map[armKey]*monitorArm),
+	}
+}
+
+// OnGuardrailTrip registers a callback invoked when CheckGuardrail starts
+// routing an experimental variant to control (once per trip, not on every
+// call while it stays tripped), so operators can page. Only one callback is
+// kept; calling this again replaces it.
+func (m *VariantMonitor) OnGuardrailTrip(fn func(key, variant, reason string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onTrip = fn
+}
+
+// Record logs one sample for (key, variant): observed latency in seconds,
+// whether the call errored, and a reward in [0, 1] (e.g. thumbs-up rating,
+// task success). Each EMA is updated independently using the time elapsed
+// since this arm's previous sample.
+func (m *VariantMonitor) Record(key, variant string, latencySeconds float64, isError bool, reward float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	arm := m.armLocked(key, variant)
+
+	errorSample := 0.0
+	if isError {
+		errorSample = 1.0
+	}
+
+	a := m.smoothingFactor(arm, now)
+	arm.latencyEMA += a * (latencySeconds - arm.latencyEMA)
+	arm.errorEMA += a * (errorSample - arm.errorEMA)
+	arm.rewardEMA += a * (reward - arm.rewardEMA)
+	arm.samples++
+	arm.lastSample = now
+}
+
+// smoothingFactor returns a = 1 - exp(-dt/tau) for the time elapsed since
+// arm's last sample, or 1.0 (take the sample at full weight) for an arm's
+// very first sample, when tau is non-positive, or when dt is non-positive
+// (two samples landing on the same clock tick shouldn't be silently
+// dropped from the averages).
+func (m *VariantMonitor) smoothingFactor(arm *monitorArm, now time.Time) float64 {
+	if arm.samples == 0 {
+		arm.start = now
+		return 1.0
+	}
+	if m.tau <= 0 {
+		return 1.0
+	}
+	dt := now.Sub(arm.lastSample).Seconds()
+	if dt <= 0 {
+		return 1.0
+	}
+	return 1 - math.Exp(-dt/m.tau.Seconds())
+}
+
+// armLocked returns the arm for (key, variant), creating it if needed.
+// Callers must hold m.mu.
+func (m *VariantMonitor) armLocked(key, variant string) *monitorArm {
+	k := armKey{key: key, variant: variant}
+	arm, ok := m.arms[k]
+	if !ok {
+		arm = &monitorArm{}
+		m.arms[k] = arm
+	}
+	return arm
+}
+
+// Status returns the current EMA values and sample count for (key, variant).
+func (m *VariantMonitor) Status(key, variant string) ArmStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	arm, ok := m.arms[armKey{key: key, variant: variant}]
+	if !ok {
+		return ArmStatus{}
+	}
+	return ArmStatus{
+		Samples:    arm.samples,
+		Since:      arm.start,
+		LatencyEMA: arm.latencyEMA,
+		ErrorEMA:   arm.errorEMA,
+		RewardEMA:  arm.rewardEMA,
+	}
+}
+
+// CheckGuardrail reports whether variant should be routed to control given
+// cfg: true means variant's EMA error rate is at least cfg.MinErrorRate and
+// exceeds cfg.ErrorRateMultiplier times control's, and both arms have at least
+// cfg.MinSamples samples. The registered OnGuardrailTrip callback (if any)
+// fires only on the false->true transition for (key, variant), not on every
+// call while the guardrail stays tripped, so operators get paged once per
+// incident rather than once per request.
+func (m *VariantMonitor) CheckGuardrail(key, control, variant string, cfg GuardrailConfig) bool {
+	if variant == control {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	controlArm, haveControl := m.arms[armKey{key: key, variant: control}]
+	variantArm, haveVariant := m.arms[armKey{key: key, variant: variant}]
+	if !haveControl || !haveVariant {
+		return false
+	}
+	if controlArm.samples < cfg.MinSamples || variantArm.samples < cfg.MinSamples {
+		return false
+	}
+
+	threshold := cfg.ErrorRateMultiplier * controlArm.errorEMA
+	trips := variantArm.errorEMA > threshold && variantArm.errorEMA >= cfg.MinErrorRate
+	if !trips {
+		variantArm.tripped = false
+		return false
+	}
+
+	if !variantArm.tripped {
+		variantArm.tripped = true
+		if m.onTrip != nil {
+			m.onTrip(key, variant, "error rate guardrail tripped")
+		}
+	}
+	return true
+}
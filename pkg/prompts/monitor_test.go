@@ -0,0 +1,223 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package prompts
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestVariantMonitor_RecordUpdatesStatus(t *testing.T) {
+	monitor := NewVariantMonitor(time.Minute)
+
+	monitor.Record("test.key", "default", 0.2, false, 1.0)
+	status := monitor.Status("test.key", "default")
+
+	if status.Samples != 1 {
+		t.Errorf("Samples = %d, want 1", status.Samples)
+	}
+	if status.LatencyEMA != 0.2 {
+		t.Errorf("LatencyEMA = %v, want 0.2 (first sample takes full weight)", status.LatencyEMA)
+	}
+	if status.ErrorEMA != 0 {
+		t.Errorf("ErrorEMA = %v, want 0", status.ErrorEMA)
+	}
+	if status.RewardEMA != 1.0 {
+		t.Errorf("RewardEMA = %v, want 1.0", status.RewardEMA)
+	}
+	if status.Since.IsZero() {
+		t.Error("Since should be set on the first sample")
+	}
+}
+
+func TestVariantMonitor_StatusForUnknownArm(t *testing.T) {
+	monitor := NewVariantMonitor(time.Minute)
+
+	status := monitor.Status("test.key", "missing")
+	if status.Samples != 0 {
+		t.Errorf("Samples = %d, want 0 for an unrecorded arm", status.Samples)
+	}
+}
+
+func TestVariantMonitor_CheckGuardrailTripsOnHighErrorRate(t *testing.T) {
+	monitor := NewVariantMonitor(time.Minute)
+	var tripped []string
+	monitor.OnGuardrailTrip(func(key, variant, reason string) {
+		tripped = append(tripped, variant)
+	})
+
+	cfg := GuardrailConfig{ErrorRateMultiplier: 2.0, MinSamples: 1, MinErrorRate: 0.1}
+
+	// Control: no errors. Experimental: always errors.
+	monitor.Record("test.key", "default", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, true, 0)
+
+	tripped1 := monitor.CheckGuardrail("test.key", "default", "experimental", cfg)
+	if !tripped1 {
+		t.Error("CheckGuardrail() should trip when the experimental error rate far exceeds control's")
+	}
+	if len(tripped) != 1 || tripped[0] != "experimental" {
+		t.Errorf("OnGuardrailTrip callback fired for %v, want [experimental]", tripped)
+	}
+}
+
+func TestVariantMonitor_CheckGuardrailFiresOnlyOnTransition(t *testing.T) {
+	// A tiny tau relative to real wall-clock gaps between calls means each
+	// sample lands at ~full weight, so the EMA tracks the latest samples
+	// closely enough for "recovery" to be observable within a fast test.
+	monitor := NewVariantMonitor(time.Nanosecond)
+	var tripCount int
+	monitor.OnGuardrailTrip(func(key, variant, reason string) {
+		tripCount++
+	})
+
+	cfg := GuardrailConfig{ErrorRateMultiplier: 2.0, MinSamples: 1, MinErrorRate: 0.1}
+
+	monitor.Record("test.key", "default", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, true, 0)
+
+	for i := 0; i < 5; i++ {
+		if !monitor.CheckGuardrail("test.key", "default", "experimental", cfg) {
+			t.Fatalf("iteration %d: CheckGuardrail() should stay tripped", i)
+		}
+	}
+	if tripCount != 1 {
+		t.Errorf("OnGuardrailTrip fired %d times across repeated calls, want 1 (fire only on the false->true transition)", tripCount)
+	}
+
+	// Recovery: experimental starts succeeding, bringing its error EMA down
+	// below the floor, so CheckGuardrail should clear and a later re-trip
+	// should page again.
+	for i := 0; i < 20; i++ {
+		monitor.Record("test.key", "experimental", 0.1, false, 1)
+	}
+	if monitor.CheckGuardrail("test.key", "default", "experimental", cfg) {
+		t.Error("CheckGuardrail() should clear once the experimental error rate recovers")
+	}
+
+	monitor.Record("test.key", "experimental", 0.1, true, 0)
+	monitor.Record("test.key", "experimental", 0.1, true, 0)
+	if !monitor.CheckGuardrail("test.key", "default", "experimental", cfg) {
+		t.Error("CheckGuardrail() should be able to re-trip after recovering")
+	}
+	if tripCount != 2 {
+		t.Errorf("OnGuardrailTrip fired %d times total, want 2 (one per incident)", tripCount)
+	}
+}
+
+func TestVariantMonitor_CheckGuardrailRequiresAbsoluteErrorRateFloor(t *testing.T) {
+	monitor := NewVariantMonitor(time.Minute)
+	cfg := GuardrailConfig{ErrorRateMultiplier: 2.0, MinSamples: 1, MinErrorRate: 0.05}
+
+	// Control is clean (EMA error rate 0), so the relative threshold
+	// (multiplier * 0) is 0. Without an absolute floor, a single
+	// experimental error would trip the guardrail; the floor should
+	// prevent that until the error rate is meaningfully above noise.
+	monitor.Record("test.key", "default", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, true, 0)
+
+	if monitor.CheckGuardrail("test.key", "default", "experimental", cfg) {
+		t.Error("CheckGuardrail() should not trip on a single error against a clean control when below the absolute floor")
+	}
+}
+
+func TestVariantMonitor_CheckGuardrailDoesNotTripWithinThreshold(t *testing.T) {
+	monitor := NewVariantMonitor(time.Minute)
+	cfg := GuardrailConfig{ErrorRateMultiplier: 2.0, MinSamples: 1}
+
+	monitor.Record("test.key", "default", 0.1, true, 0)
+	monitor.Record("test.key", "experimental", 0.1, true, 0)
+
+	if monitor.CheckGuardrail("test.key", "default", "experimental", cfg) {
+		t.Error("CheckGuardrail() should not trip when error rates are comparable")
+	}
+}
+
+func TestVariantMonitor_CheckGuardrailRequiresMinSamples(t *testing.T) {
+	monitor := NewVariantMonitor(time.Minute)
+	cfg := GuardrailConfig{ErrorRateMultiplier: 2.0, MinSamples: 5}
+
+	monitor.Record("test.key", "default", 0.1, false, 1)
+	monitor.Record("test.key", "experimental", 0.1, true, 0)
+
+	if monitor.CheckGuardrail("test.key", "default", "experimental", cfg) {
+		t.Error("CheckGuardrail() should not trip a new, under-sampled arm")
+	}
+}
+
+func TestVariantMonitor_CheckGuardrailIgnoresControlVariant(t *testing.T) {
+	monitor := NewVariantMonitor(time.Minute)
+	cfg := GuardrailConfig{ErrorRateMultiplier: 0, MinSamples: 0}
+
+	monitor.Record("test.key", "default", 0.1, true, 0)
+
+	if monitor.CheckGuardrail("test.key", "default", "default", cfg) {
+		t.Error("CheckGuardrail() should never trip the control variant against itself")
+	}
+}
+
+func TestABTestingRegistry_GuardrailRoutesToControl(t *testing.T) {
+	mock := newMockRegistry()
+	mock.addPrompt("test.prompt", "default", "Default")
+	mock.addPrompt("test.prompt", "experimental", "Experimental")
+	mock.metadata["test.prompt"] = &PromptMetadata{
+		Key:      "test.prompt",
+		Variants: []string{"default", "experimental"},
+	}
+
+	monitor := NewVariantMonitor(time.Minute)
+	monitor.Record("test.prompt", "default", 0.1, false, 1)
+	monitor.Record("test.prompt", "experimental", 0.1, true, 0)
+
+	abRegistry := NewABTestingRegistry(mock, NewExplicitSelector("experimental"))
+	abRegistry.WithMonitor(monitor, GuardrailConfig{ErrorRateMultiplier: 2.0, MinSamples: 1})
+
+	result, err := abRegistry.GetForSession(context.Background(), "test.prompt", "sess-123", nil)
+	if err != nil {
+		t.Fatalf("GetForSession() failed: %v", err)
+	}
+	if result != "Default" {
+		t.Errorf("got %q, want %q once the guardrail has tripped", result, "Default")
+	}
+}
+
+func TestABTestingRegistry_WithoutMonitorServesSelectedVariant(t *testing.T) {
+	mock := newMockRegistry()
+	mock.addPrompt("test.prompt", "default", "Default")
+	mock.addPrompt("test.prompt", "experimental", "Experimental")
+	mock.metadata["test.prompt"] = &PromptMetadata{
+		Key:      "test.prompt",
+		Variants: []string{"default", "experimental"},
+	}
+
+	abRegistry := NewABTestingRegistry(mock, NewExplicitSelector("experimental"))
+
+	result, err := abRegistry.GetForSession(context.Background(), "test.prompt", "sess-123", nil)
+	if err != nil {
+		t.Fatalf("GetForSession() failed: %v", err)
+	}
+	if result != "Experimental" {
+		t.Errorf("got %q, want %q with no monitor configured", result, "Experimental")
+	}
+}
@@ -24,9 +24,38 @@ import (
 
 // Valid MCP transport types
 var validTransports = map[string]bool{
-	"stdio": true,
-	"http":  true,
-	"sse":   true,
+	"stdio":     true,
+	"http":      true,
+	"sse":       true,
+	"websocket": true,
+	"unix":      true,
+}
+
+// validateTransportFields checks that the fields required by a given
+// transport are present: "command" for stdio, "url" for http/sse/websocket,
+// "socket_path" for unix. An empty transport is treated as "stdio" to match
+// the default applied elsewhere.
+func validateTransportFields(transportType, command, url, socketPath string) error {
+	if transportType == "" {
+		transportType = "stdio"
+	}
+
+	switch transportType {
+	case "stdio":
+		if command == "" {
+			return fmt.Errorf("command is required for stdio transport")
+		}
+	case "http", "sse", "websocket":
+		if url == "" {
+			return fmt.Errorf("url is required for %s transport", transportType)
+		}
+	case "unix":
+		if socketPath == "" {
+			return fmt.Errorf("socket_path is required for unix transport")
+		}
+	}
+
+	return nil
 }
 
 // ListMCPServers lists all configured MCP servers.
@@ -124,9 +153,6 @@ func (s *MultiAgentServer) AddMCPServer(ctx context.Context, req *loomv1.AddMCPS
 	if req.Name == "" {
 		return nil, status.Error(codes.InvalidArgument, "server name is required")
 	}
-	if req.Command == "" {
-		return nil, status.Error(codes.InvalidArgument, "command is required")
-	}
 
 	// Set default transport
 	if req.Transport == "" {
@@ -135,7 +161,11 @@ func (s *MultiAgentServer) AddMCPServer(ctx context.Context, req *loomv1.AddMCPS
 
 	// Validate transport type
 	if !validTransports[req.Transport] {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid transport type: %s (must be stdio, http, or sse)", req.Transport)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transport type: %s (must be stdio, http, sse, websocket, or unix)", req.Transport)
+	}
+
+	if err := validateTransportFields(req.Transport, req.Command, req.Url, req.SocketPath); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	// Validate command exists (for stdio transport)
@@ -186,6 +216,8 @@ func (s *MultiAgentServer) AddMCPServer(ctx context.Context, req *loomv1.AddMCPS
 		Env:              req.Env,
 		Transport:        req.Transport,
 		URL:              req.Url, // Note: req.Url from proto (lowercase 'rl')
+		Headers:          req.Headers,
+		SocketPath:       req.SocketPath,
 		EnableSessions:   req.EnableSessions,
 		EnableResumption: req.EnableResumption,
 		Enabled:          req.Enabled,
@@ -296,13 +328,14 @@ func (s *MultiAgentServer) UpdateMCPServer(ctx context.Context, req *loomv1.Upda
 	if req.ServerName == "" {
 		return nil, status.Error(codes.InvalidArgument, "server name is required")
 	}
-	if req.Command == "" {
-		return nil, status.Error(codes.InvalidArgument, "command is required")
-	}
 
 	// Validate transport type
 	if req.Transport != "" && !validTransports[req.Transport] {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid transport type: %s (must be stdio, http, or sse)", req.Transport)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transport type: %s (must be stdio, http, sse, websocket, or unix)", req.Transport)
+	}
+
+	if err := validateTransportFields(req.Transport, req.Command, req.Url, req.SocketPath); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	// Validate command exists (for stdio transport)
@@ -357,6 +390,8 @@ func (s *MultiAgentServer) UpdateMCPServer(ctx context.Context, req *loomv1.Upda
 		Env:              req.Env,
 		Transport:        req.Transport,
 		URL:              req.Url, // Note: req.Url from proto (lowercase 'rl')
+		Headers:          req.Headers,
+		SocketPath:       req.SocketPath,
 		EnableSessions:   req.EnableSessions,
 		EnableResumption: req.EnableResumption,
 		Enabled:          req.Enabled,
@@ -560,14 +595,6 @@ func (s *MultiAgentServer) HealthCheckMCPServers(ctx context.Context, req *loomv
 func (s *MultiAgentServer) TestMCPServerConnection(ctx context.Context, req *loomv1.TestMCPServerConnectionRequest) (*loomv1.TestMCPServerConnectionResponse, error) {
 	startTime := time.Now()
 
-	// Validate required fields
-	if req.Command == "" {
-		return &loomv1.TestMCPServerConnectionResponse{
-			Success: false,
-			Error:   "command is required",
-		}, nil
-	}
-
 	// Set default transport
 	if req.Transport == "" {
 		req.Transport = "stdio"
@@ -577,7 +604,16 @@ func (s *MultiAgentServer) TestMCPServerConnection(ctx context.Context, req *loo
 	if !validTransports[req.Transport] {
 		return &loomv1.TestMCPServerConnectionResponse{
 			Success: false,
-			Error:   fmt.Sprintf("invalid transport type: %s (must be stdio, http, or sse)", req.Transport),
+			Error:   fmt.Sprintf("invalid transport type: %s (must be stdio, http, sse, websocket, or unix)", req.Transport),
+		}, nil
+	}
+
+	// Validate the fields the selected transport actually needs (command for
+	// stdio, url for http/sse/websocket, socket_path for unix).
+	if err := validateTransportFields(req.Transport, req.Command, req.Url, req.SocketPath); err != nil {
+		return &loomv1.TestMCPServerConnectionResponse{
+			Success: false,
+			Error:   err.Error(),
 		}, nil
 	}
 
@@ -636,6 +672,9 @@ func (s *MultiAgentServer) TestMCPServerConnection(ctx context.Context, req *loo
 		Args:       req.Args,
 		Env:        req.Env,
 		Transport:  req.Transport,
+		URL:        req.Url, // Note: req.Url from proto (lowercase 'rl')
+		Headers:    req.Headers,
+		SocketPath: req.SocketPath,
 		Enabled:    true,
 		ToolFilter: toolFilter,
 	}
@@ -686,16 +725,32 @@ func (s *MultiAgentServer) TestMCPServerConnection(ctx context.Context, req *loo
 		}, nil
 	}
 
-	// Note: ServerCapabilities are not currently exposed by the client
-	// We just report successful connection and tool count
+	// Probe the rest of the server's advertised capabilities so the dialog
+	// can show the full surface area, not just tool count.
+	caps := client.ServerCapabilities()
+
+	var resourceCount, promptCount int32
+	if caps.Resources != nil {
+		if resources, err := client.ListResources(testCtx); err == nil {
+			resourceCount = int32(len(resources))
+		}
+	}
+	if caps.Prompts != nil {
+		if prompts, err := client.ListPrompts(testCtx); err == nil {
+			promptCount = int32(len(prompts))
+		}
+	}
 
 	latency := time.Since(startTime).Milliseconds()
 
 	return &loomv1.TestMCPServerConnectionResponse{
-		Success:   true,
-		Message:   fmt.Sprintf("Successfully connected and discovered %d tools", len(tools)),
-		ToolCount: int32(len(tools)),
-		LatencyMs: latency,
+		Success: true,
+		Message: fmt.Sprintf("Successfully connected: %d tools, %d resources, %d prompts",
+			len(tools), resourceCount, promptCount),
+		ToolCount:     int32(len(tools)),
+		ResourceCount: resourceCount,
+		PromptCount:   promptCount,
+		LatencyMs:     latency,
 	}, nil
 }
 
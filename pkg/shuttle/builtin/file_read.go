@@ -6,14 +6,21 @@
 package builtin
 
 import (
+	"bufio"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
+
 	"github.com/teradata-labs/loom/pkg/shuttle"
 )
 
@@ -24,8 +31,42 @@ const (
 
 	// DefaultMaxLines limits text output to prevent context bloat.
 	DefaultMaxLines = 1000
+
+	// defaultFollowPollInterval is how often follow mode re-stats the file
+	// while waiting on the fsnotify watcher for a wake-up.
+	defaultFollowPollInterval = 200 * time.Millisecond
+
+	// maxFollowDeadline caps how long a single Execute call will block in
+	// follow mode, regardless of what the caller requests.
+	maxFollowDeadline = 60 * time.Second
 )
 
+// readToken is the opaque cursor FileReadTool hands back so a caller can
+// resume a paged read from exactly where the previous call left off. It is
+// not a security boundary -- just a compact encoding of position.
+type readToken struct {
+	Path       string `json:"path"`
+	ByteOffset int64  `json:"byte_offset"`
+	Line       int    `json:"line"` // 1-based line the next read should start at; 0 in tokens from byte-range reads
+}
+
+func encodeReadToken(tok readToken) string {
+	data, _ := json.Marshal(tok)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeReadToken(s string) (readToken, error) {
+	var tok readToken
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return tok, fmt.Errorf("invalid read_token: %w", err)
+	}
+	if err := json.Unmarshal(data, &tok); err != nil {
+		return tok, fmt.Errorf("invalid read_token: %w", err)
+	}
+	return tok, nil
+}
+
 // FileReadTool provides safe file reading capabilities for agents.
 // Enables data grounding by reading actual file content rather than guessing.
 //
@@ -67,7 +108,13 @@ Use this tool to:
 - Load configuration or results files
 - Read markdown, JSON, XML, or other text files
 
-Safety: Won't read sensitive system files. Max file size: 10MB.
+Safety: Won't read sensitive system files. Max file size: 10MB, unless offset/length or
+read_token paging is used to stream a larger file in bounded chunks.
+
+Streaming large files: pass "offset" and "length" for a byte range, or "read_token" (from a
+previous response) to resume a line-scan exactly where it left off. Each response includes a
+sha256 of the bytes covered so callers can detect the file changing between paged reads, and a
+"follow": true mode that blocks (up to "follow_timeout_ms") for new data appended to a log file.
 
 RECOMMENDED ALTERNATIVES:
 - workspace tool: action=read, scope=artifact (session-scoped, indexed, searchable)
@@ -84,6 +131,12 @@ func (t *FileReadTool) InputSchema() *shuttle.JSONSchema {
 				WithDefault("text"),
 			"max_lines":  shuttle.NewNumberSchema("Maximum lines to return for text files (default: 1000, 0 = unlimited)"),
 			"start_line": shuttle.NewNumberSchema("Start reading from this line number (1-based, default: 1)"),
+			"offset":     shuttle.NewNumberSchema("Byte offset to start reading from. Bypasses the 10MB size limit; returns raw bytes rather than lines."),
+			"length":     shuttle.NewNumberSchema("Number of bytes to read starting at offset (required if offset is set)"),
+			"read_token": shuttle.NewStringSchema("Resume token from a previous response's read_token field. Continues a line-based paged read from where it left off."),
+			"follow": shuttle.NewBooleanSchema("If true, and the requested range is at end-of-file, block waiting for the file to grow (e.g. tailing an append-only log) instead of returning an empty read.").
+				WithDefault(false),
+			"follow_timeout_ms": shuttle.NewNumberSchema("Maximum time to block in follow mode, in milliseconds (default: 5000, max: 60000)"),
 		},
 		[]string{"path"},
 	)
@@ -179,6 +232,15 @@ func (t *FileReadTool) Execute(ctx context.Context, params map[string]interface{
 		}, nil
 	}
 
+	// Streaming paths: byte-range reads and line-paged reads via read_token both
+	// bypass MaxFileReadSize since they touch a bounded slice of the file at a time.
+	if _, hasOffset := params["offset"]; hasOffset {
+		return t.executeByteRange(cleanPath, info, params, start)
+	}
+	if tokStr, hasToken := params["read_token"].(string); hasToken && tokStr != "" {
+		return t.executePagedLines(cleanPath, tokStr, params, start)
+	}
+
 	// Check file size
 	if info.Size() > MaxFileReadSize {
 		return &shuttle.Result{
@@ -209,6 +271,8 @@ func (t *FileReadTool) Execute(ctx context.Context, params map[string]interface{
 	var totalLines int
 	var returnedLines int
 	var truncated bool
+	var nextReadToken string
+	coveredBytes := data
 
 	if encoding == "base64" {
 		// Binary mode: return base64-encoded content
@@ -217,8 +281,9 @@ func (t *FileReadTool) Execute(ctx context.Context, params map[string]interface{
 		returnedLines = 0
 	} else {
 		// Text mode: handle line limits
-		lines := strings.Split(string(data), "\n")
-		totalLines = len(lines)
+		allLines := strings.Split(string(data), "\n")
+		totalLines = len(allLines)
+		lines := allLines
 
 		// Apply start_line (1-based)
 		if startLine > 1 {
@@ -237,19 +302,122 @@ func (t *FileReadTool) Execute(ctx context.Context, params map[string]interface{
 
 		returnedLines = len(lines)
 		content = strings.Join(lines, "\n")
+		coveredBytes = []byte(content)
+
+		if truncated {
+			// Callers that need to keep paging past max_lines (without holding
+			// the whole file in memory on the next call) resume via the
+			// lazy-scanning path in executePagedLines using this token.
+			nextLine := startLine + returnedLines
+			nextReadToken = encodeReadToken(readToken{
+				Path:       cleanPath,
+				ByteOffset: lineStartByteOffset(allLines, nextLine),
+				Line:       nextLine,
+			})
+		}
+	}
+
+	resultData := map[string]interface{}{
+		"path":        cleanPath,
+		"content":     content,
+		"encoding":    encoding,
+		"size_bytes":  info.Size(),
+		"total_lines": totalLines,
+		"lines_read":  returnedLines,
+		"start_line":  startLine,
+		"truncated":   truncated,
+		"modified_at": info.ModTime().Format(time.RFC3339),
+		"sha256":      sha256Hex(coveredBytes),
+	}
+	if nextReadToken != "" {
+		resultData["read_token"] = nextReadToken
 	}
 
+	return &shuttle.Result{
+		Success: true,
+		Data:    resultData,
+		Metadata: map[string]interface{}{
+			"file_path": cleanPath,
+			"size":      info.Size(),
+		},
+		ExecutionTimeMs: time.Since(start).Milliseconds(),
+	}, nil
+}
+
+func (t *FileReadTool) Backend() string {
+	return "" // Backend-agnostic
+}
+
+// executeByteRange serves a byte-range read using io.SectionReader, bypassing
+// MaxFileReadSize entirely. Used when the caller supplies "offset"/"length"
+// directly rather than paging by line.
+func (t *FileReadTool) executeByteRange(cleanPath string, info os.FileInfo, params map[string]interface{}, start time.Time) (*shuttle.Result, error) {
+	offset, _ := toInt64(params["offset"])
+	if offset < 0 {
+		return errorResult("INVALID_PARAMS", "offset must be >= 0", "", start), nil
+	}
+
+	length, hasLength := toInt64(params["length"])
+	if !hasLength || length <= 0 {
+		return errorResult("INVALID_PARAMS", "length is required and must be > 0 when offset is set", "", start), nil
+	}
+
+	follow, followDeadline := followParams(params)
+
+	f, err := os.OpenFile(cleanPath, os.O_RDONLY, 0)
+	if err != nil {
+		return errorResult("READ_FAILED", fmt.Sprintf("Failed to open file: %v", err), "", start), nil
+	}
+	defer f.Close()
+
+	deadline := time.Now().Add(followDeadline)
+	var buf []byte
+	var n int
+	for {
+		section := io.NewSectionReader(f, offset, length)
+		buf = make([]byte, length)
+		n, err = io.ReadFull(section, buf)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return errorResult("READ_FAILED", fmt.Sprintf("Failed to read file: %v", err), "", start), nil
+		}
+		if n > 0 || !follow || time.Now().After(deadline) {
+			break
+		}
+		if !waitForGrowth(cleanPath, info.Size(), deadline) {
+			break
+		}
+		if st, statErr := os.Stat(cleanPath); statErr == nil {
+			info = st
+		}
+	}
+	buf = buf[:n]
+
+	encoding := "text"
+	if e, ok := params["encoding"].(string); ok && e != "" {
+		encoding = e
+	}
+
+	var content string
+	if encoding == "base64" {
+		content = base64.StdEncoding.EncodeToString(buf)
+	} else {
+		content = string(buf)
+	}
+
+	nextToken := encodeReadToken(readToken{Path: cleanPath, ByteOffset: offset + int64(n)})
+
 	return &shuttle.Result{
 		Success: true,
 		Data: map[string]interface{}{
 			"path":        cleanPath,
 			"content":     content,
 			"encoding":    encoding,
+			"offset":      offset,
+			"length":      int64(n),
 			"size_bytes":  info.Size(),
-			"total_lines": totalLines,
-			"lines_read":  returnedLines,
-			"start_line":  startLine,
-			"truncated":   truncated,
+			"eof":         offset+int64(n) >= info.Size(),
+			"sha256":      sha256Hex(buf),
+			"read_token":  nextToken,
 			"modified_at": info.ModTime().Format(time.RFC3339),
 		},
 		Metadata: map[string]interface{}{
@@ -260,8 +428,225 @@ func (t *FileReadTool) Execute(ctx context.Context, params map[string]interface{
 	}, nil
 }
 
-func (t *FileReadTool) Backend() string {
-	return "" // Backend-agnostic
+// executePagedLines resumes a line-based scan from a previously issued
+// read_token, using a buffered scanner seeked to the token's byte offset so
+// the whole file never has to be held in memory regardless of its size.
+func (t *FileReadTool) executePagedLines(cleanPath string, tokStr string, params map[string]interface{}, start time.Time) (*shuttle.Result, error) {
+	tok, err := decodeReadToken(tokStr)
+	if err != nil {
+		return errorResult("INVALID_PARAMS", err.Error(), "", start), nil
+	}
+	if tok.Path != cleanPath {
+		return errorResult("INVALID_PARAMS", "read_token was issued for a different path", "", start), nil
+	}
+
+	maxLines := DefaultMaxLines
+	if m, ok := params["max_lines"].(float64); ok && m > 0 {
+		maxLines = int(m)
+	}
+
+	follow, followDeadline := followParams(params)
+	deadline := time.Now().Add(followDeadline)
+
+	for {
+		info, err := os.Stat(cleanPath)
+		if err != nil {
+			return errorResult("STAT_FAILED", fmt.Sprintf("Failed to stat file: %v", err), "", start), nil
+		}
+
+		f, err := os.OpenFile(cleanPath, os.O_RDONLY, 0)
+		if err != nil {
+			return errorResult("READ_FAILED", fmt.Sprintf("Failed to open file: %v", err), "", start), nil
+		}
+
+		lines, bytesRead, readErr := scanLinesFrom(f, tok.ByteOffset, maxLines)
+		f.Close()
+		if readErr != nil {
+			return errorResult("READ_FAILED", fmt.Sprintf("Failed to read file: %v", readErr), "", start), nil
+		}
+
+		if len(lines) > 0 || !follow || time.Now().After(deadline) {
+			content := strings.Join(lines, "\n")
+			nextTok := encodeReadToken(readToken{
+				Path:       cleanPath,
+				ByteOffset: tok.ByteOffset + bytesRead,
+				Line:       tok.Line + len(lines),
+			})
+
+			return &shuttle.Result{
+				Success: true,
+				Data: map[string]interface{}{
+					"path":        cleanPath,
+					"content":     content,
+					"encoding":    "text",
+					"start_line":  tok.Line,
+					"lines_read":  len(lines),
+					"size_bytes":  info.Size(),
+					"eof":         tok.ByteOffset+bytesRead >= info.Size(),
+					"sha256":      sha256Hex([]byte(content)),
+					"read_token":  nextTok,
+					"modified_at": info.ModTime().Format(time.RFC3339),
+				},
+				Metadata: map[string]interface{}{
+					"file_path": cleanPath,
+					"size":      info.Size(),
+				},
+				ExecutionTimeMs: time.Since(start).Milliseconds(),
+			}, nil
+		}
+
+		if !waitForGrowth(cleanPath, info.Size(), deadline) {
+			continue // deadline reached; loop once more to return the (empty) result
+		}
+	}
+}
+
+// scanLinesFrom seeks to offset and reads up to maxLines complete lines,
+// returning the lines (without trailing "\n") and the number of bytes
+// consumed from offset. A trailing partial line (no terminating "\n" yet,
+// e.g. a writer mid-append) is left unread so a later call picks it up whole.
+func scanLinesFrom(f *os.File, offset int64, maxLines int) ([]string, int64, error) {
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+
+	reader := bufio.NewReaderSize(f, 64*1024)
+	var lines []string
+	var consumed int64
+	for len(lines) < maxLines {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			// Partial/unterminated trailing line: don't consume it so the
+			// next page starts from its beginning once it's complete.
+			break
+		}
+		if err != nil {
+			return lines, consumed, err
+		}
+		consumed += int64(len(line))
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	return lines, consumed, nil
+}
+
+// lineStartByteOffset returns the byte offset where line n (1-based) begins,
+// given the file already split on "\n". Returns the file length if n is past
+// the end.
+func lineStartByteOffset(lines []string, n int) int64 {
+	var offset int64
+	for i := 0; i < n-1 && i < len(lines); i++ {
+		offset += int64(len(lines[i]))
+		if i < len(lines)-1 {
+			offset++ // the "\n" stripped by the split; the last element has none
+		}
+	}
+	return offset
+}
+
+// followParams extracts the "follow"/"follow_timeout_ms" params, clamping the
+// deadline to maxFollowDeadline.
+func followParams(params map[string]interface{}) (bool, time.Duration) {
+	follow, _ := params["follow"].(bool)
+	if !follow {
+		return false, 0
+	}
+	deadline := 5 * time.Second
+	if ms, ok := params["follow_timeout_ms"].(float64); ok && ms > 0 {
+		deadline = time.Duration(ms) * time.Millisecond
+	}
+	if deadline > maxFollowDeadline {
+		deadline = maxFollowDeadline
+	}
+	return true, deadline
+}
+
+// waitForGrowth blocks until path grows past lastSize or the deadline
+// passes. It prefers an fsnotify watch on
+// the file's directory (so it wakes promptly on append) and falls back to
+// polling at defaultFollowPollInterval if the watch can't be established.
+// Returns true if the file grew, false if the deadline was reached first.
+func waitForGrowth(path string, lastSize int64, deadline time.Time) bool {
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		defer watcher.Close()
+		if watchErr := watcher.Add(filepath.Dir(path)); watchErr != nil {
+			watcher.Close()
+			watcher = nil
+		}
+	} else {
+		watcher = nil
+	}
+
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false
+		}
+		wait := defaultFollowPollInterval
+		if wait > remaining {
+			wait = remaining
+		}
+
+		if watcher != nil {
+			select {
+			case event := <-watcher.Events:
+				if event.Name == path || filepath.Clean(event.Name) == filepath.Clean(path) {
+					if st, err := os.Stat(path); err == nil && st.Size() > lastSize {
+						return true
+					}
+				}
+				continue
+			case <-watcher.Errors:
+				continue
+			case <-time.After(wait):
+			}
+		} else {
+			time.Sleep(wait)
+		}
+
+		if st, err := os.Stat(path); err == nil && st.Size() > lastSize {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+	}
+}
+
+// toInt64 extracts an int64 from a JSON-decoded numeric param (always
+// float64) or a literal int/int64, returning ok=false if absent/wrong type.
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int64(n), true
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	}
+	return 0, false
+}
+
+// sha256Hex returns the hex-encoded sha256 digest of data, used so callers
+// paging through a large file can detect concurrent modification between
+// calls by comparing digests of the ranges they've already read.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// errorResult builds a failed shuttle.Result consistent with the inline error
+// construction used throughout Execute.
+func errorResult(code, message, suggestion string, start time.Time) *shuttle.Result {
+	return &shuttle.Result{
+		Success: false,
+		Error: &shuttle.Error{
+			Code:       code,
+			Message:    message,
+			Suggestion: suggestion,
+		},
+		ExecutionTimeMs: time.Since(start).Milliseconds(),
+	}
 }
 
 // isSensitiveReadPath checks if a path is in a sensitive system location.
@@ -8,6 +8,7 @@ package builtin
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -217,6 +218,72 @@ func TestFileReadTool_Execute_SensitivePath(t *testing.T) {
 	assert.Equal(t, "UNSAFE_PATH", result.Error.Code)
 }
 
+func TestFileReadTool_Execute_ByteRange(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "range.txt")
+	content := "0123456789ABCDEF"
+	err := os.WriteFile(testFile, []byte(content), 0644)
+	require.NoError(t, err)
+
+	tool := NewFileReadTool(tmpDir)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"path":   "range.txt",
+		"offset": float64(4),
+		"length": float64(6),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	data := result.Data.(map[string]interface{})
+	assert.Equal(t, "456789", data["content"])
+	assert.Equal(t, int64(4), data["offset"])
+	assert.Equal(t, int64(6), data["length"])
+	assert.NotEmpty(t, data["sha256"])
+	assert.NotEmpty(t, data["read_token"])
+}
+
+func TestFileReadTool_Execute_ReadTokenResume(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "paged.txt")
+
+	var lines []string
+	for i := 0; i < 30; i++ {
+		lines = append(lines, fmt.Sprintf("line-%d", i))
+	}
+	content := strings.Join(lines, "\n")
+	err := os.WriteFile(testFile, []byte(content), 0644)
+	require.NoError(t, err)
+
+	tool := NewFileReadTool(tmpDir)
+
+	first, err := tool.Execute(context.Background(), map[string]interface{}{
+		"path":      "paged.txt",
+		"max_lines": float64(10),
+	})
+	require.NoError(t, err)
+	require.True(t, first.Success)
+
+	firstData := first.Data.(map[string]interface{})
+	assert.True(t, firstData["truncated"].(bool))
+	token, ok := firstData["read_token"].(string)
+	require.True(t, ok)
+	require.NotEmpty(t, token)
+
+	second, err := tool.Execute(context.Background(), map[string]interface{}{
+		"path":       "paged.txt",
+		"read_token": token,
+		"max_lines":  float64(10),
+	})
+	require.NoError(t, err)
+	require.True(t, second.Success)
+
+	secondData := second.Data.(map[string]interface{})
+	assert.Equal(t, "line-10\nline-11\nline-12\nline-13\nline-14\nline-15\nline-16\nline-17\nline-18\nline-19", secondData["content"])
+	assert.Equal(t, 11, secondData["start_line"])
+}
+
 func TestFileReadTool_Backend(t *testing.T) {
 	tool := NewFileReadTool("")
 	assert.Empty(t, tool.Backend())
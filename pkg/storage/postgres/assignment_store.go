@@ -0,0 +1,142 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+	"github.com/teradata-labs/loom/pkg/prompts"
+)
+
+// AssignmentStore implements prompts.AssignmentStore using PostgreSQL, so
+// sticky variant assignments survive restarts and are shared across
+// instances. Assignments are not tenant-scoped: they describe the
+// experiment's state, not any one user's data, so operations run outside
+// row-level security via execInTxNoRLS.
+type AssignmentStore struct {
+	pool   *pgxpool.Pool
+	tracer observability.Tracer
+}
+
+// NewAssignmentStore creates a new PostgreSQL-backed assignment store.
+func NewAssignmentStore(pool *pgxpool.Pool, tracer observability.Tracer) *AssignmentStore {
+	if tracer == nil {
+		tracer = observability.NewNoOpTracer()
+	}
+	return &AssignmentStore{
+		pool:   pool,
+		tracer: tracer,
+	}
+}
+
+// Get implements prompts.AssignmentStore.
+func (s *AssignmentStore) Get(ctx context.Context, key, sessionID string) (string, bool, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "pg_assignment_store.get")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("key", key)
+
+	var (
+		variant   string
+		found     bool
+		expiresAt *time.Time
+	)
+	err := execInTxNoRLS(ctx, s.pool, func(ctx context.Context, tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			SELECT variant, expires_at FROM prompt_assignments
+			WHERE key = $1 AND session_id = $2`,
+			key, sessionID,
+		).Scan(&variant, &expiresAt)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return nil
+			}
+			return fmt.Errorf("failed to get assignment: %w", err)
+		}
+		found = true
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return "", false, err
+	}
+	if !found {
+		return "", false, nil
+	}
+	if expiresAt != nil && time.Now().After(*expiresAt) {
+		return "", false, nil
+	}
+	return variant, true, nil
+}
+
+// Put implements prompts.AssignmentStore.
+func (s *AssignmentStore) Put(ctx context.Context, key, sessionID, variant string, expiresAt time.Time) error {
+	ctx, span := s.tracer.StartSpan(ctx, "pg_assignment_store.put")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("key", key)
+
+	var expiresAtArg *time.Time
+	if !expiresAt.IsZero() {
+		expiresAtArg = &expiresAt
+	}
+
+	err := execInTxNoRLS(ctx, s.pool, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO prompt_assignments (key, session_id, variant, expires_at, updated_at)
+			VALUES ($1, $2, $3, $4, NOW())
+			ON CONFLICT (key, session_id) DO UPDATE SET
+				variant = $3,
+				expires_at = $4,
+				updated_at = NOW()`,
+			key, sessionID, variant, expiresAtArg,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to put assignment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// Delete implements prompts.AssignmentStore.
+func (s *AssignmentStore) Delete(ctx context.Context, key, sessionID string) error {
+	ctx, span := s.tracer.StartSpan(ctx, "pg_assignment_store.delete")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("key", key)
+
+	err := execInTxNoRLS(ctx, s.pool, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `DELETE FROM prompt_assignments WHERE key = $1 AND session_id = $2`, key, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to delete assignment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// Compile-time check: AssignmentStore implements prompts.AssignmentStore.
+var _ prompts.AssignmentStore = (*AssignmentStore)(nil)
@@ -0,0 +1,110 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+	"github.com/teradata-labs/loom/pkg/prompts"
+)
+
+// BanditStore implements prompts.BanditStore using PostgreSQL, so that
+// multi-armed bandit arm state (and thus a running experiment's learned
+// weights) survives process restarts. Arm state is not tenant-scoped: it
+// describes the experiment itself, not any one user's data, so operations
+// run outside row-level security via execInTxNoRLS.
+type BanditStore struct {
+	pool   *pgxpool.Pool
+	tracer observability.Tracer
+}
+
+// NewBanditStore creates a new PostgreSQL-backed bandit store.
+func NewBanditStore(pool *pgxpool.Pool, tracer observability.Tracer) *BanditStore {
+	if tracer == nil {
+		tracer = observability.NewNoOpTracer()
+	}
+	return &BanditStore{
+		pool:   pool,
+		tracer: tracer,
+	}
+}
+
+// Get implements prompts.BanditStore.
+func (s *BanditStore) Get(ctx context.Context, key, variant string) (prompts.ArmStats, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "pg_bandit_store.get")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("key", key)
+	span.SetAttribute("variant", variant)
+
+	stats := prompts.ArmStats{Alpha: 1, Beta: 1}
+	err := execInTxNoRLS(ctx, s.pool, func(ctx context.Context, tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			SELECT alpha, beta FROM bandit_arms WHERE key = $1 AND variant = $2`,
+			key, variant,
+		).Scan(&stats.Alpha, &stats.Beta)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return nil
+			}
+			return fmt.Errorf("failed to get arm stats: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return prompts.ArmStats{}, err
+	}
+	return stats, nil
+}
+
+// Update implements prompts.BanditStore, folding reward into the arm's
+// Beta posterior with an upsert so the first pull creates the row from the
+// uniform prior.
+func (s *BanditStore) Update(ctx context.Context, key, variant string, reward float64) (prompts.ArmStats, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "pg_bandit_store.update")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("key", key)
+	span.SetAttribute("variant", variant)
+
+	stats := prompts.ArmStats{}
+	err := execInTxNoRLS(ctx, s.pool, func(ctx context.Context, tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO bandit_arms (key, variant, alpha, beta)
+			VALUES ($1, $2, 1 + $3, 1 + (1 - $3))
+			ON CONFLICT (key, variant) DO UPDATE SET
+				alpha = bandit_arms.alpha + $3,
+				beta = bandit_arms.beta + (1 - $3),
+				updated_at = NOW()
+			RETURNING alpha, beta`,
+			key, variant, reward,
+		).Scan(&stats.Alpha, &stats.Beta)
+		if err != nil {
+			return fmt.Errorf("failed to update arm stats: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return prompts.ArmStats{}, err
+	}
+	return stats, nil
+}
+
+// Compile-time check: BanditStore implements prompts.BanditStore.
+var _ prompts.BanditStore = (*BanditStore)(nil)